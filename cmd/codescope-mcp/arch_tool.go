@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"codescope-mcp/internal/arch"
+	"codescope-mcp/internal/mcp"
+)
+
+type architectureLayersArgs struct {
+	Path string `json:"path"`
+}
+
+func registerArchTools(srv *mcp.Server) {
+	srv.RegisterTool(mcp.Tool{
+		Name:        "architecture_layers",
+		Description: "Classifies Go types into Clean Architecture / DDD layers and reports cross-layer import violations",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"path": map[string]any{
+					"type":        "string",
+					"description": "Module root (or subtree) to analyze",
+				},
+			},
+			"required": []string{"path"},
+		},
+		Handler: func(ctx context.Context, raw json.RawMessage) (any, error) {
+			var args architectureLayersArgs
+			if err := json.Unmarshal(raw, &args); err != nil {
+				return nil, fmt.Errorf("invalid arguments: %w", err)
+			}
+			if args.Path == "" {
+				return nil, fmt.Errorf("path is required")
+			}
+			return arch.AnalyzeDir(args.Path)
+		},
+	})
+}
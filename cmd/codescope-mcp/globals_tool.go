@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"codescope-mcp/internal/globals"
+	"codescope-mcp/internal/mcp"
+)
+
+type mutableGlobalDetectorArgs struct {
+	Path string   `json:"path"`
+	Fix  *fixArgs `json:"fix,omitempty"`
+}
+
+type fixArgs struct {
+	Var string `json:"var"`
+}
+
+func registerGlobalsTools(srv *mcp.Server) {
+	srv.RegisterTool(mcp.Tool{
+		Name:        "mutable_global_detector",
+		Description: "Flags unsynchronized package-level mutable state reachable from goroutines, HTTP handlers, or exported APIs, and proposes a thread-safe refactor",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"path": map[string]any{"type": "string", "description": "File or directory to analyze"},
+				"fix": map[string]any{
+					"type":        "object",
+					"description": "If set, mechanically apply the atomic-counter refactor for the named var instead of just reporting",
+					"properties": map[string]any{
+						"var": map[string]any{"type": "string"},
+					},
+					"required": []string{"var"},
+				},
+			},
+			"required": []string{"path"},
+		},
+		Handler: func(ctx context.Context, raw json.RawMessage) (any, error) {
+			var args mutableGlobalDetectorArgs
+			if err := json.Unmarshal(raw, &args); err != nil {
+				return nil, fmt.Errorf("invalid arguments: %w", err)
+			}
+			if args.Path == "" {
+				return nil, fmt.Errorf("path is required")
+			}
+
+			if args.Fix != nil {
+				info, err := os.Stat(args.Path)
+				if err != nil {
+					return nil, fmt.Errorf("stat %s: %w", args.Path, err)
+				}
+				if info.IsDir() {
+					return nil, fmt.Errorf("fix mode requires path to be a single file")
+				}
+				if err := globals.ApplyAtomicFix(args.Path, args.Fix.Var); err != nil {
+					return nil, err
+				}
+				return map[string]any{"fixed": args.Fix.Var, "file": args.Path}, nil
+			}
+
+			info, err := os.Stat(args.Path)
+			if err != nil {
+				return nil, fmt.Errorf("stat %s: %w", args.Path, err)
+			}
+			if info.IsDir() {
+				return globals.DetectDir(args.Path)
+			}
+			return globals.DetectFile(args.Path)
+		},
+	})
+}
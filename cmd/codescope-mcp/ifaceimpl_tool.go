@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"codescope-mcp/internal/ifaceimpl"
+	"codescope-mcp/internal/mcp"
+)
+
+type interfaceImplementersArgs struct {
+	Path      string `json:"path"`
+	Interface string `json:"interface"`
+}
+
+func registerIfaceImplTools(srv *mcp.Server) {
+	srv.RegisterTool(mcp.Tool{
+		Name:        "interface_implementers",
+		Description: "Resolves concrete implementers of a Go interface, their usage and call sites, and per-method removal impact",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"path": map[string]any{
+					"type":        "string",
+					"description": "Root of the target module (must contain a go.mod)",
+				},
+				"interface": map[string]any{
+					"type":        "string",
+					"description": "Bare interface type name, e.g. Validatable",
+				},
+			},
+			"required": []string{"path", "interface"},
+		},
+		Handler: func(ctx context.Context, raw json.RawMessage) (any, error) {
+			var args interfaceImplementersArgs
+			if err := json.Unmarshal(raw, &args); err != nil {
+				return nil, fmt.Errorf("invalid arguments: %w", err)
+			}
+			if args.Path == "" || args.Interface == "" {
+				return nil, fmt.Errorf("path and interface are required")
+			}
+			return ifaceimpl.Analyze(args.Path, args.Interface)
+		},
+	})
+}
@@ -0,0 +1,25 @@
+// Command codescope-mcp runs the codescope MCP server on stdio. It
+// exposes a set of code-analysis tools that Go-aware MCP clients can
+// call against a target module.
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"codescope-mcp/internal/mcp"
+)
+
+func main() {
+	srv := mcp.NewServer("codescope-mcp", "0.1.0")
+	registerValidationTools(srv)
+	registerArchTools(srv)
+	registerIfaceImplTools(srv)
+	registerTodoScanTools(srv)
+	registerGlobalsTools(srv)
+
+	if err := srv.Serve(context.Background(), os.Stdin, os.Stdout); err != nil {
+		log.Fatalf("codescope-mcp: %v", err)
+	}
+}
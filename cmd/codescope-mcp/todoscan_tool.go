@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"codescope-mcp/internal/mcp"
+	"codescope-mcp/internal/todoscan"
+)
+
+type todoScannerArgs struct {
+	Path     string   `json:"path"`
+	Markers  []string `json:"markers"`
+	RepoRoot string   `json:"repo_root"`
+}
+
+type todoConvertArgs struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Mode    string `json:"mode"`
+	IssueID string `json:"issue_id"`
+}
+
+func registerTodoScanTools(srv *mcp.Server) {
+	srv.RegisterTool(mcp.Tool{
+		Name:        "todo_scanner",
+		Description: "Extracts TODO/FIXME/HACK/NOTE markers with enclosing decl, git blame, and inferred severity, sorted by staleness",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"path":      map[string]any{"type": "string", "description": "File or directory to scan"},
+				"markers":   map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Override the marker set (default FIXME, TODO, HACK, NOTE)"},
+				"repo_root": map[string]any{"type": "string", "description": "Git working tree root, to enrich markers with blame info"},
+			},
+			"required": []string{"path"},
+		},
+		Handler: func(ctx context.Context, raw json.RawMessage) (any, error) {
+			var args todoScannerArgs
+			if err := json.Unmarshal(raw, &args); err != nil {
+				return nil, fmt.Errorf("invalid arguments: %w", err)
+			}
+			if args.Path == "" {
+				return nil, fmt.Errorf("path is required")
+			}
+			opts := todoscan.ScanOptions{Markers: args.Markers, RepoRoot: args.RepoRoot}
+
+			markers, err := scanPath(args.Path, opts)
+			if err != nil {
+				return nil, err
+			}
+			todoscan.SortByStaleness(markers)
+			return map[string]any{"markers": markers}, nil
+		},
+	})
+
+	srv.RegisterTool(mcp.Tool{
+		Name:        "todo_convert",
+		Description: "Rewrites a single TODO/FIXME marker into a //go: directive or an issue-tracker stub, returning a diff",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"file":     map[string]any{"type": "string"},
+				"line":     map[string]any{"type": "integer"},
+				"mode":     map[string]any{"type": "string", "enum": []string{"pragma", "issue_stub"}},
+				"issue_id": map[string]any{"type": "string", "description": "Only used for mode=issue_stub"},
+			},
+			"required": []string{"file", "line", "mode"},
+		},
+		Handler: func(ctx context.Context, raw json.RawMessage) (any, error) {
+			var args todoConvertArgs
+			if err := json.Unmarshal(raw, &args); err != nil {
+				return nil, fmt.Errorf("invalid arguments: %w", err)
+			}
+			if args.File == "" || args.Line == 0 || args.Mode == "" {
+				return nil, fmt.Errorf("file, line, and mode are required")
+			}
+
+			markers, err := todoscan.ScanFile(args.File, todoscan.ScanOptions{})
+			if err != nil {
+				return nil, err
+			}
+			for _, m := range markers {
+				if m.Line == args.Line {
+					return todoscan.ConvertMarker(m, todoscan.ConvertMode(args.Mode), args.IssueID)
+				}
+			}
+			return nil, fmt.Errorf("no TODO/FIXME marker found at %s:%d", args.File, args.Line)
+		},
+	})
+}
+
+func scanPath(path string, opts todoscan.ScanOptions) ([]todoscan.Marker, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("stat %s: %w", path, err)
+	}
+	if info.IsDir() {
+		return todoscan.ScanDir(path, opts)
+	}
+	return todoscan.ScanFile(path, opts)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"codescope-mcp/internal/mcp"
+	"codescope-mcp/internal/validation"
+)
+
+type detectValidationPatternsArgs struct {
+	Path string `json:"path"`
+}
+
+func registerValidationTools(srv *mcp.Server) {
+	srv.RegisterTool(mcp.Tool{
+		Name:        "detect_validation_patterns",
+		Description: "Flags ad-hoc validation logic (Validate/IsValid methods, validateXxx helpers) and proposes go-playground/validator struct tags",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"path": map[string]any{
+					"type":        "string",
+					"description": "File or package directory to analyze",
+				},
+			},
+			"required": []string{"path"},
+		},
+		Handler: func(ctx context.Context, raw json.RawMessage) (any, error) {
+			var args detectValidationPatternsArgs
+			if err := json.Unmarshal(raw, &args); err != nil {
+				return nil, fmt.Errorf("invalid arguments: %w", err)
+			}
+			if args.Path == "" {
+				return nil, fmt.Errorf("path is required")
+			}
+
+			info, err := os.Stat(args.Path)
+			if err != nil {
+				return nil, fmt.Errorf("stat %s: %w", args.Path, err)
+			}
+			if info.IsDir() {
+				return validation.AnalyzeDir(args.Path)
+			}
+			return validation.AnalyzeFile(filepath.Clean(args.Path))
+		},
+	})
+}
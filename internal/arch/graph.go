@@ -0,0 +1,344 @@
+package arch
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FileReport is the layer assignment for one file, aggregated from the
+// types it declares.
+type FileReport struct {
+	Path          string               `json:"path"`
+	Package       string               `json:"package"`
+	DominantLayer Layer                `json:"dominant_layer"`
+	Types         []TypeClassification `json:"types"`
+	Imports       []string             `json:"imports"`
+}
+
+// Violation is a single disallowed dependency between layers.
+type Violation struct {
+	FromFile  string `json:"from_file"`
+	FromLayer Layer  `json:"from_layer"`
+	Import    string `json:"import"`
+	ToLayer   Layer  `json:"to_layer,omitempty"`
+	Reason    string `json:"reason"`
+}
+
+// Report is the result of analyzing a directory tree.
+type Report struct {
+	Files      []FileReport `json:"files"`
+	Violations []Violation  `json:"violations"`
+	Mermaid    string       `json:"mermaid_diagram"`
+}
+
+// allowedDependency reports whether a file classified as `from` is
+// permitted to import a package whose files are dominantly `to`.
+// Clean Architecture points all dependencies inward: controller/infra ->
+// usecase -> repository_port -> domain. repository_adapter depends on
+// repository_port, domain, and other adapters only (never the reverse).
+func allowedDependency(from, to Layer) bool {
+	switch from {
+	case LayerDomain:
+		return to == LayerDomain || to == LayerUnknown
+	case LayerRepositoryPort:
+		return to == LayerDomain || to == LayerRepositoryPort || to == LayerUnknown
+	case LayerRepositoryAdapter:
+		return to == LayerDomain || to == LayerRepositoryPort || to == LayerRepositoryAdapter || to == LayerUnknown
+	case LayerUseCase:
+		return to != LayerController && to != LayerInfra
+	default: // controller, infra, unknown may depend on anything
+		return true
+	}
+}
+
+// AnalyzeDir classifies every .go file under root (recursively) and
+// reports the import graph between directories (packages), flagging
+// edges that violate allowedDependency.
+func AnalyzeDir(root string) (*Report, error) {
+	modulePath, err := readModulePath(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []FileReport
+	layerByDir := make(map[string][]Layer)
+
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		fr, err := classifyFile(path)
+		if err != nil {
+			return fmt.Errorf("arch: %w", err)
+		}
+		files = append(files, *fr)
+		layerByDir[filepath.Dir(path)] = append(layerByDir[filepath.Dir(path)], fr.DominantLayer)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dirLayer := make(map[string]Layer)
+	for dir, layers := range layerByDir {
+		dirLayer[dir] = majority(layers)
+	}
+
+	var violations []Violation
+	edges := make(map[dirEdge]bool)
+	for _, fr := range files {
+		fromDir := filepath.Dir(fr.Path)
+		fromLayer := dirLayer[fromDir]
+		for _, imp := range fr.Imports {
+			if sig := infraSignal(imp); sig != "" && fromLayer == LayerDomain {
+				violations = append(violations, Violation{
+					FromFile: fr.Path, FromLayer: fromLayer, Import: imp, ToLayer: LayerInfra,
+					Reason: "domain type imports infrastructure package " + sig,
+				})
+				continue
+			}
+			if sig := infraSignal(imp); sig != "" && fromLayer == LayerUseCase {
+				violations = append(violations, Violation{
+					FromFile: fr.Path, FromLayer: fromLayer, Import: imp, ToLayer: LayerInfra,
+					Reason: "use case imports infrastructure package " + sig + " directly instead of depending on a repository port",
+				})
+				continue
+			}
+			toDir := resolveLocalImport(root, modulePath, imp)
+			if toDir == "" {
+				continue
+			}
+			toLayer, ok := dirLayer[toDir]
+			if toDir != fromDir {
+				markEdge(edges, dirEdge{fromDir, toDir}, !ok || !allowedDependency(fromLayer, toLayer))
+			}
+			if !ok || allowedDependency(fromLayer, toLayer) {
+				continue
+			}
+			violations = append(violations, Violation{
+				FromFile: fr.Path, FromLayer: fromLayer, Import: imp, ToLayer: toLayer,
+				Reason: fmt.Sprintf("%s must not depend on %s", fromLayer, toLayer),
+			})
+		}
+	}
+
+	return &Report{
+		Files:      files,
+		Violations: violations,
+		Mermaid:    renderMermaid(dirLayer, edges),
+	}, nil
+}
+
+// dirEdge is one actual import edge between two directories (packages).
+type dirEdge struct {
+	from, to string
+}
+
+// markEdge records that from imports to, and whether that edge
+// violates allowedDependency. A pair already marked as a violation by
+// one file's import is never downgraded by a later, allowed import of
+// the same pair from a different file.
+func markEdge(edges map[dirEdge]bool, key dirEdge, violation bool) {
+	if violation {
+		edges[key] = true
+		return
+	}
+	if _, ok := edges[key]; !ok {
+		edges[key] = false
+	}
+}
+
+func classifyFile(path string) (*FileReport, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	methodsByRecv := make(map[string][]*ast.FuncDecl)
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil {
+			continue
+		}
+		recv := receiverTypeName(fn)
+		methodsByRecv[recv] = append(methodsByRecv[recv], fn)
+	}
+
+	var classifications []TypeClassification
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			classifications = append(classifications, classifyType(ts, methodsByRecv[ts.Name.Name]))
+		}
+	}
+
+	var imports []string
+	for _, imp := range file.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		imports = append(imports, path)
+	}
+
+	var layers []Layer
+	for _, c := range classifications {
+		layers = append(layers, c.Layer)
+	}
+
+	return &FileReport{
+		Path:          path,
+		Package:       file.Name.Name,
+		DominantLayer: majority(layers),
+		Types:         classifications,
+		Imports:       imports,
+	}, nil
+}
+
+func receiverTypeName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	switch t := fn.Recv.List[0].Type.(type) {
+	case *ast.StarExpr:
+		if id, ok := t.X.(*ast.Ident); ok {
+			return id.Name
+		}
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+func majority(layers []Layer) Layer {
+	if len(layers) == 0 {
+		return LayerUnknown
+	}
+	counts := make(map[Layer]int)
+	for _, l := range layers {
+		if l == LayerUnknown {
+			continue
+		}
+		counts[l]++
+	}
+	best, bestCount := LayerUnknown, 0
+	for l, c := range counts {
+		if c > bestCount {
+			best, bestCount = l, c
+		}
+	}
+	return best
+}
+
+func infraSignal(imp string) string {
+	for _, sig := range infraImportSignals {
+		if imp == sig {
+			return sig
+		}
+	}
+	return ""
+}
+
+// resolveLocalImport maps an import path back to a directory under root
+// when it is the module itself or a subpackage of it (modulePath, as
+// declared in root's go.mod). It returns "" for anything it can't
+// confidently resolve, such as third-party imports, or when root has no
+// go.mod (modulePath == "").
+func resolveLocalImport(root, modulePath, imp string) string {
+	if modulePath == "" {
+		return ""
+	}
+	if imp == modulePath {
+		return root
+	}
+	rel := strings.TrimPrefix(imp, modulePath+"/")
+	if rel == imp {
+		return "" // imp is not modulePath or a subpackage of it
+	}
+	candidate := filepath.Join(root, filepath.FromSlash(rel))
+	if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+		return candidate
+	}
+	return ""
+}
+
+// readModulePath reads the `module` directive from root/go.mod. It
+// returns "" (not an error) when root has no go.mod, since codescope-mcp
+// can still classify layers without one — it just can't resolve local
+// import paths back to directories.
+func readModulePath(root string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("arch: read go.mod: %w", err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "module ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "module")), nil
+		}
+	}
+	return "", fmt.Errorf("arch: go.mod at %s has no module directive", root)
+}
+
+// renderMermaid draws one node per package directory, colored by its
+// dominant layer, and one edge per actual import between two
+// directories. An edge that violates allowedDependency is drawn dashed
+// and labeled, so the diagram shows allowed vs. actual dependencies at
+// a glance rather than just layer membership.
+func renderMermaid(dirLayer map[string]Layer, edges map[dirEdge]bool) string {
+	var dirs []string
+	for d := range dirLayer {
+		dirs = append(dirs, d)
+	}
+	sort.Strings(dirs)
+
+	var sb strings.Builder
+	sb.WriteString("graph TD\n")
+	for _, d := range dirs {
+		fmt.Fprintf(&sb, "  %s[%q]:::%s\n", sanitize(d), filepath.Base(d), dirLayer[d])
+	}
+
+	var keys []dirEdge
+	for k := range edges {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].from != keys[j].from {
+			return keys[i].from < keys[j].from
+		}
+		return keys[i].to < keys[j].to
+	})
+	for _, k := range keys {
+		if edges[k] {
+			fmt.Fprintf(&sb, "  %s -. violates .-> %s\n", sanitize(k.from), sanitize(k.to))
+			continue
+		}
+		fmt.Fprintf(&sb, "  %s --> %s\n", sanitize(k.from), sanitize(k.to))
+	}
+	return sb.String()
+}
+
+func sanitize(s string) string {
+	return strings.NewReplacer("/", "_", ".", "_", "-", "_").Replace(s)
+}
@@ -0,0 +1,54 @@
+package arch
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeDirFixtures(t *testing.T) {
+	report, err := AnalyzeDir("../../tests/fixtures")
+	if err != nil {
+		t.Fatalf("AnalyzeDir: %v", err)
+	}
+	if len(report.Files) == 0 {
+		t.Fatal("expected at least one classified file")
+	}
+
+	var sample *FileReport
+	for i := range report.Files {
+		if filepath.Base(report.Files[i].Path) == "sample.go" {
+			sample = &report.Files[i]
+		}
+	}
+	if sample == nil {
+		t.Fatal("expected sample.go to be classified")
+	}
+	if len(sample.Types) == 0 {
+		t.Fatal("expected sample.go to declare classified types")
+	}
+}
+
+func TestAnalyzeDirCrossPackageViolation(t *testing.T) {
+	report, err := AnalyzeDir("testdata/cleanarch")
+	if err != nil {
+		t.Fatalf("AnalyzeDir: %v", err)
+	}
+
+	var found *Violation
+	for i := range report.Violations {
+		if report.Violations[i].FromLayer == LayerDomain && report.Violations[i].ToLayer == LayerRepositoryAdapter {
+			found = &report.Violations[i]
+		}
+	}
+	if found == nil {
+		t.Fatalf("expected a domain -> repository_adapter violation (domain importing infra), got %+v", report.Violations)
+	}
+	if found.Import != "example.com/cleanarch/infra" {
+		t.Errorf("violation import = %q, want %q", found.Import, "example.com/cleanarch/infra")
+	}
+
+	if !strings.Contains(report.Mermaid, "_domain -. violates .-> ") || !strings.Contains(report.Mermaid, "_infra\n") {
+		t.Errorf("expected the mermaid diagram to render the domain -> infra edge as a violation, got:\n%s", report.Mermaid)
+	}
+}
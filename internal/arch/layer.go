@@ -0,0 +1,196 @@
+// Package arch classifies Go types and files into Clean
+// Architecture / DDD layers and reports cross-layer import violations.
+package arch
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// Layer is one of the recognized architectural layers.
+type Layer string
+
+const (
+	LayerDomain            Layer = "domain"
+	LayerRepositoryPort    Layer = "repository_port"
+	LayerRepositoryAdapter Layer = "repository_adapter"
+	LayerUseCase           Layer = "usecase"
+	LayerController        Layer = "controller"
+	LayerInfra             Layer = "infra"
+	LayerUnknown           Layer = "unknown"
+)
+
+// infraImportSignals are import paths that mark a file as touching
+// infrastructure even when none of its types embed a recognizable
+// client type directly.
+var infraImportSignals = []string{
+	"database/sql",
+	"net/http",
+	"gorm.io/gorm",
+	"github.com/gin-gonic/gin",
+	"github.com/jmoiron/sqlx",
+}
+
+var crudMethodNames = map[string]bool{
+	"Create": true, "Get": true, "Find": true, "FindByID": true,
+	"Update": true, "Delete": true, "List": true, "Save": true,
+	"GetByID": true, "FindAll": true,
+}
+
+// TypeClassification is the layer assigned to one declared type, along
+// with a 0-1 confidence and the signals that produced it.
+type TypeClassification struct {
+	Name       string   `json:"name"`
+	Layer      Layer    `json:"layer"`
+	Confidence float64  `json:"confidence"`
+	Signals    []string `json:"signals"`
+}
+
+// classifyType inspects a single type declaration and its associated
+// methods (the methods declared on it as receiver in the same file) to
+// assign it a layer.
+func classifyType(ts *ast.TypeSpec, methods []*ast.FuncDecl) TypeClassification {
+	name := ts.Name.Name
+	switch t := ts.Type.(type) {
+	case *ast.InterfaceType:
+		return classifyInterface(name, t)
+	case *ast.StructType:
+		return classifyStruct(name, t, methods)
+	default:
+		return TypeClassification{Name: name, Layer: LayerUnknown, Confidence: 0}
+	}
+}
+
+func classifyInterface(name string, it *ast.InterfaceType) TypeClassification {
+	crud := 0
+	total := 0
+	for _, m := range it.Methods.List {
+		if _, ok := m.Type.(*ast.FuncType); !ok {
+			continue
+		}
+		total++
+		for _, n := range m.Names {
+			if crudMethodNames[n.Name] {
+				crud++
+			}
+		}
+	}
+	if (strings.HasSuffix(name, "Repository") || strings.HasSuffix(name, "Service")) && total > 0 && crud > 0 {
+		confidence := float64(crud) / float64(total)
+		return TypeClassification{Name: name, Layer: LayerRepositoryPort, Confidence: 0.5 + 0.5*confidence,
+			Signals: []string{"interface name suffix Repository/Service", "CRUD-style method set"}}
+	}
+	return TypeClassification{Name: name, Layer: LayerUnknown, Confidence: 0.2}
+}
+
+func classifyStruct(name string, st *ast.StructType, methods []*ast.FuncDecl) TypeClassification {
+	var signals []string
+
+	embedsInfraClient := false
+	for _, f := range st.Fields.List {
+		if len(f.Names) != 0 {
+			continue // not an embedded field
+		}
+		if sel, ok := f.Type.(*ast.SelectorExpr); ok {
+			if isInfraClientType(sel) {
+				embedsInfraClient = true
+				signals = append(signals, "embeds "+sel.Sel.Name)
+			}
+		}
+		if star, ok := f.Type.(*ast.StarExpr); ok {
+			if sel, ok := star.X.(*ast.SelectorExpr); ok && isInfraClientType(sel) {
+				embedsInfraClient = true
+				signals = append(signals, "embeds *"+sel.Sel.Name)
+			}
+		}
+	}
+	if embedsInfraClient {
+		return TypeClassification{Name: name, Layer: LayerRepositoryAdapter, Confidence: 0.9, Signals: signals}
+	}
+
+	methodNames := make(map[string]bool)
+	for _, m := range methods {
+		methodNames[m.Name.Name] = true
+	}
+	if methodNames["ServeHTTP"] || strings.HasSuffix(name, "Handler") || strings.HasSuffix(name, "Controller") {
+		return TypeClassification{Name: name, Layer: LayerController, Confidence: 0.8,
+			Signals: []string{"ServeHTTP or Handler/Controller naming"}}
+	}
+
+	holdsPort := false
+	for _, f := range st.Fields.List {
+		typeName := exprTypeName(f.Type)
+		if strings.HasSuffix(typeName, "Repository") || strings.HasSuffix(typeName, "Service") {
+			holdsPort = true
+		}
+	}
+	if holdsPort && (methodNames["Run"] || methodNames["Execute"] || methodNames["Handle"]) {
+		return TypeClassification{Name: name, Layer: LayerUseCase, Confidence: 0.85,
+			Signals: []string{"holds Repository/Service field", "Run/Execute/Handle method"}}
+	}
+
+	if len(methods) == 0 || isSimpleInvariantMethods(methods) {
+		return TypeClassification{Name: name, Layer: LayerDomain, Confidence: 0.6,
+			Signals: []string{"data fields with simple invariant methods only"}}
+	}
+
+	return TypeClassification{Name: name, Layer: LayerUnknown, Confidence: 0.2}
+}
+
+func isInfraClientType(sel *ast.SelectorExpr) bool {
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	switch pkg.Name + "." + sel.Sel.Name {
+	case "sql.DB", "gorm.DB", "http.Client", "sqlx.DB":
+		return true
+	}
+	return false
+}
+
+func exprTypeName(e ast.Expr) string {
+	switch t := e.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return exprTypeName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	default:
+		return ""
+	}
+}
+
+// isSimpleInvariantMethods reports whether every selector call in the
+// given methods is a call into the strings, fmt, or errors package (a
+// rough proxy for "no I/O, no external dependency"). Any other selector
+// call, including a method call on the receiver, makes the set
+// non-simple; plain function calls are not counted.
+func isSimpleInvariantMethods(methods []*ast.FuncDecl) bool {
+	for _, m := range methods {
+		simple := true
+		ast.Inspect(m, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			if pkg, ok := sel.X.(*ast.Ident); ok {
+				switch pkg.Name {
+				case "strings", "fmt", "errors":
+					return true
+				}
+			}
+			simple = false
+			return true
+		})
+		if !simple {
+			return false
+		}
+	}
+	return true
+}
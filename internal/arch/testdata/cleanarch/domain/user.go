@@ -0,0 +1,19 @@
+package domain
+
+import "example.com/cleanarch/infra"
+
+// User is a plain domain entity with an invariant check.
+type User struct {
+	Name string
+}
+
+// IsValid reports whether the user satisfies its invariants.
+func (u *User) IsValid() bool {
+	return u.Name != ""
+}
+
+// NewRepo is a Clean Architecture violation on purpose: domain code must
+// not depend on the infra layer.
+func NewRepo() *infra.UserRepo {
+	return infra.NewUserRepo()
+}
@@ -0,0 +1,13 @@
+package infra
+
+import "database/sql"
+
+// UserRepo is a repository adapter backed by database/sql.
+type UserRepo struct {
+	*sql.DB
+}
+
+// NewUserRepo constructs a UserRepo.
+func NewUserRepo() *UserRepo {
+	return &UserRepo{}
+}
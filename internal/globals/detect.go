@@ -0,0 +1,296 @@
+// Package globals flags package-level mutable state (the
+// `var globalCounter int` style of shared, unsynchronized state) and
+// checks whether any mutation site is reachable from a goroutine start,
+// an HTTP handler, or an exported function.
+package globals
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// MutationKind distinguishes the shape of a mutation, since it drives
+// which refactor is proposed.
+type MutationKind string
+
+const (
+	MutationIncDec MutationKind = "incdec" // x++ / x--
+	MutationAssign MutationKind = "assign" // x = ... or x += ...
+)
+
+// MutationSite is one place a global is written to.
+type MutationSite struct {
+	Func string       `json:"func"`
+	Line int          `json:"line"`
+	Kind MutationKind `json:"kind"`
+	Expr string       `json:"expr"`
+}
+
+// Finding is one package-level var and its reachability analysis.
+type Finding struct {
+	Name          string         `json:"name"`
+	Type          string         `json:"type"`
+	File          string         `json:"file"`
+	Line          int            `json:"line"`
+	MutationSites []MutationSite `json:"mutation_sites"`
+	ReachableFrom []string       `json:"reachable_from_roots"`
+	RaceRisk      bool           `json:"race_risk"`
+	SuggestedFix  string         `json:"suggested_fix"`
+	Patch         string         `json:"patch"`
+}
+
+// DetectFile analyzes the package-level vars of a single file.
+func DetectFile(path string) ([]Finding, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("globals: parse %s: %w", path, err)
+	}
+
+	globalVars := collectGlobalVars(file)
+	if len(globalVars) == 0 {
+		return nil, nil
+	}
+
+	calls := buildCallGraph(file)
+	roots := findRoots(file, calls)
+
+	var findings []Finding
+	names := make([]string, 0, len(globalVars))
+	for name := range globalVars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		gv := globalVars[name]
+		sites := findMutationSites(fset, file, name)
+		if len(sites) == 0 {
+			continue // never mutated outside declaration: not a finding
+		}
+
+		reachable := reachableRoots(sites, calls, roots)
+		finding := Finding{
+			Name:          name,
+			Type:          gv.typeName,
+			File:          path,
+			Line:          fset.Position(gv.pos).Line,
+			MutationSites: sites,
+			ReachableFrom: reachable,
+			RaceRisk:      len(reachable) > 0,
+		}
+		finding.SuggestedFix, finding.Patch = proposeFix(gv, sites)
+		findings = append(findings, finding)
+	}
+	return findings, nil
+}
+
+// DetectDir recursively analyzes every .go file under root.
+func DetectDir(root string) ([]Finding, error) {
+	var out []Finding
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		findings, err := DetectFile(path)
+		if err != nil {
+			return err
+		}
+		out = append(out, findings...)
+		return nil
+	})
+	return out, err
+}
+
+type globalVar struct {
+	name     string
+	typeName string
+	pos      token.Pos
+}
+
+func collectGlobalVars(file *ast.File) map[string]globalVar {
+	out := make(map[string]globalVar)
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok || vs.Type == nil {
+				continue
+			}
+			typeName := exprToString(vs.Type)
+			for _, n := range vs.Names {
+				if n.Name == "_" {
+					continue
+				}
+				out[n.Name] = globalVar{name: n.Name, typeName: typeName, pos: n.Pos()}
+			}
+		}
+	}
+	return out
+}
+
+func exprToString(e ast.Expr) string {
+	switch t := e.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return "*" + exprToString(t.X)
+	case *ast.SelectorExpr:
+		return exprToString(t.X) + "." + t.Sel.Name
+	case *ast.ArrayType:
+		return "[]" + exprToString(t.Elt)
+	case *ast.MapType:
+		return "map[" + exprToString(t.Key) + "]" + exprToString(t.Value)
+	default:
+		return ""
+	}
+}
+
+// findMutationSites scans every function (excluding init) for
+// assignments or inc/dec statements targeting name.
+func findMutationSites(fset *token.FileSet, file *ast.File, name string) []MutationSite {
+	var sites []MutationSite
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil || fn.Name.Name == "init" {
+			continue
+		}
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			switch s := n.(type) {
+			case *ast.IncDecStmt:
+				if id, ok := s.X.(*ast.Ident); ok && id.Name == name {
+					sites = append(sites, MutationSite{
+						Func: fn.Name.Name, Line: fset.Position(s.Pos()).Line,
+						Kind: MutationIncDec, Expr: name + s.Tok.String(),
+					})
+				}
+			case *ast.AssignStmt:
+				for _, lhs := range s.Lhs {
+					if id, ok := lhs.(*ast.Ident); ok && id.Name == name {
+						sites = append(sites, MutationSite{
+							Func: fn.Name.Name, Line: fset.Position(s.Pos()).Line,
+							Kind: MutationAssign, Expr: name + " " + s.Tok.String() + " ...",
+						})
+					}
+				}
+			}
+			return true
+		})
+	}
+	return sites
+}
+
+// buildCallGraph returns direct, intra-file caller -> callee edges for
+// plain function calls (not method calls), which is enough to trace
+// reachability from roots down to the functions that mutate a global in
+// the common single-file case this tool targets.
+func buildCallGraph(file *ast.File) map[string][]string {
+	calls := make(map[string][]string)
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil {
+			continue
+		}
+		var callees []string
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok {
+				if id, ok := call.Fun.(*ast.Ident); ok {
+					callees = append(callees, id.Name)
+				}
+			}
+			if goStmt, ok := n.(*ast.GoStmt); ok {
+				if id, ok := goStmt.Call.Fun.(*ast.Ident); ok {
+					callees = append(callees, "go:"+id.Name)
+				}
+			}
+			return true
+		})
+		calls[fn.Name.Name] = callees
+	}
+	return calls
+}
+
+// findRoots returns the set of function names considered reachability
+// roots: exported functions, functions that look like HTTP handlers, and
+// functions launched via a `go` statement.
+func findRoots(file *ast.File, calls map[string][]string) map[string]bool {
+	roots := make(map[string]bool)
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		if fn.Recv == nil && isExported(fn.Name.Name) {
+			roots[fn.Name.Name] = true
+		}
+		if fn.Name.Name == "ServeHTTP" || strings.HasSuffix(fn.Name.Name, "Handler") {
+			roots[fn.Name.Name] = true
+		}
+	}
+	for _, callees := range calls {
+		for _, c := range callees {
+			if strings.HasPrefix(c, "go:") {
+				roots[strings.TrimPrefix(c, "go:")] = true
+			}
+		}
+	}
+	return roots
+}
+
+func isExported(name string) bool {
+	if name == "" {
+		return false
+	}
+	return unicode.IsUpper(rune(name[0]))
+}
+
+// reachableRoots returns, for the given mutation sites, which roots can
+// reach at least one of the mutating functions via calls (a depth-first
+// search over the intra-file call graph, see canReach).
+func reachableRoots(sites []MutationSite, calls map[string][]string, roots map[string]bool) []string {
+	mutators := make(map[string]bool)
+	for _, s := range sites {
+		mutators[s.Func] = true
+	}
+
+	var hit []string
+	for root := range roots {
+		if canReach(root, mutators, calls, make(map[string]bool)) {
+			hit = append(hit, root)
+		}
+	}
+	sort.Strings(hit)
+	return hit
+}
+
+// canReach is a depth-first search over the intra-file call graph that
+// reports whether fn (directly or transitively) calls one of targets.
+func canReach(fn string, targets map[string]bool, calls map[string][]string, visited map[string]bool) bool {
+	if targets[fn] {
+		return true
+	}
+	if visited[fn] {
+		return false
+	}
+	visited[fn] = true
+	for _, callee := range calls[fn] {
+		callee = strings.TrimPrefix(callee, "go:")
+		if canReach(callee, targets, calls, visited) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+package globals
+
+import "testing"
+
+func TestDetectFileSample(t *testing.T) {
+	findings, err := DetectFile("../../tests/fixtures/sample.go")
+	if err != nil {
+		t.Fatalf("DetectFile: %v", err)
+	}
+
+	var counter *Finding
+	for i := range findings {
+		if findings[i].Name == "globalCounter" {
+			counter = &findings[i]
+		}
+	}
+	if counter == nil {
+		t.Fatal("expected a finding for globalCounter")
+	}
+	if counter.SuggestedFix != "atomic counter" {
+		t.Errorf("expected atomic counter fix, got %q", counter.SuggestedFix)
+	}
+	if len(counter.ReachableFrom) == 0 {
+		t.Error("expected globalCounter's mutation (via NewUser, called from main) to be reachable from an exported/root function")
+	}
+}
@@ -0,0 +1,204 @@
+package globals
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// proposeFix picks one of the three refactors called out for mutable
+// package-level state and renders a before/after patch for it:
+//
+//   - an integer counter mutated only by ++/-- becomes the matching
+//     atomic type (atomic.Int64, Int32, Uint64, or Uint32), since that's
+//     a pure, local change;
+//   - a mutated var of any other named type (struct, slice, map, string,
+//     float, ...) becomes a struct wrapping the value with a sync.Mutex;
+//   - anything else (an integer assigned with = or +=, or a type this
+//     tool can't render) is flagged for threading through a constructor
+//     (dependency injection), since it needs call-site changes this tool
+//     can't make mechanically.
+func proposeFix(gv globalVar, sites []MutationSite) (string, string) {
+	if isIntType(gv.typeName) && allIncDec(sites) {
+		return "atomic counter", renderAtomicPatch(gv)
+	}
+	if gv.typeName != "" && !isIntType(gv.typeName) {
+		return "mutex-guarded struct", renderMutexPatch(gv)
+	}
+	return "constructor injection", renderDIPatch(gv)
+}
+
+func isIntType(t string) bool {
+	switch t {
+	case "int", "int32", "int64", "uint", "uint32", "uint64":
+		return true
+	default:
+		return false
+	}
+}
+
+func allIncDec(sites []MutationSite) bool {
+	for _, s := range sites {
+		if s.Kind != MutationIncDec {
+			return false
+		}
+	}
+	return len(sites) > 0
+}
+
+func atomicTypeFor(t string) string {
+	switch t {
+	case "int64", "int":
+		return "atomic.Int64"
+	case "int32":
+		return "atomic.Int32"
+	case "uint64", "uint":
+		return "atomic.Uint64"
+	case "uint32":
+		return "atomic.Uint32"
+	default:
+		return "atomic.Int64"
+	}
+}
+
+func renderAtomicPatch(gv globalVar) string {
+	atomicType := atomicTypeFor(gv.typeName)
+	return fmt.Sprintf(
+		"--- a/globals.go\n+++ b/globals.go\n-var %s %s\n+var %s %s\n\n-%s++\n+%s.Add(1)\n",
+		gv.name, gv.typeName, gv.name, atomicType, gv.name, gv.name,
+	)
+}
+
+func renderMutexPatch(gv globalVar) string {
+	return fmt.Sprintf(
+		"--- a/globals.go\n+++ b/globals.go\n-var %s %s\n+var %s = struct {\n+\tmu sync.Mutex\n+\tvalue %s\n+}{}\n",
+		gv.name, gv.typeName, gv.name, gv.typeName,
+	)
+}
+
+func renderDIPatch(gv globalVar) string {
+	return fmt.Sprintf(
+		"--- a/globals.go\n+++ b/globals.go\n-var %s %s\n+// %s moved into a constructor-injected dependency; see NewService.\n",
+		gv.name, gv.typeName, gv.name,
+	)
+}
+
+// ApplyAtomicFix rewrites a plain int counter (`var name int`) to an
+// atomic.Int64 in place: the var declaration gets the atomic type, and
+// every `name++`/`name--` line becomes `name.Add(1)`/`name.Add(-1)`. It
+// only handles the incdec-only case; anything else returns an error
+// asking for the mutex or constructor-injection refactor to be applied
+// by hand.
+func ApplyAtomicFix(path, name string) error {
+	findings, err := DetectFile(path)
+	if err != nil {
+		return err
+	}
+	var target *Finding
+	for i := range findings {
+		if findings[i].Name == name {
+			target = &findings[i]
+		}
+	}
+	if target == nil {
+		return fmt.Errorf("globals: %s has no mutable global named %q", path, name)
+	}
+	if target.SuggestedFix != "atomic counter" {
+		return fmt.Errorf("globals: %s is not a pure integer inc/dec counter; apply the %s refactor by hand", name, target.SuggestedFix)
+	}
+
+	lines, err := readLines(path)
+	if err != nil {
+		return err
+	}
+
+	declPattern := regexp.MustCompile(`^(\s*var\s+)` + name + `(\s+)(\w+)(\s*)$`)
+	incPattern := regexp.MustCompile(`^(\s*)` + name + `\+\+(\s*)$`)
+	decPattern := regexp.MustCompile(`^(\s*)` + name + `--(\s*)$`)
+
+	for i, line := range lines {
+		if m := declPattern.FindStringSubmatch(line); m != nil {
+			lines[i] = m[1] + name + m[2] + atomicTypeFor(m[3]) + m[4]
+			continue
+		}
+		if m := incPattern.FindStringSubmatch(line); m != nil {
+			lines[i] = m[1] + name + ".Add(1)" + m[2]
+			continue
+		}
+		if m := decPattern.FindStringSubmatch(line); m != nil {
+			lines[i] = m[1] + name + ".Add(-1)" + m[2]
+		}
+	}
+
+	lines = ensureAtomicImport(lines)
+
+	return os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644)
+}
+
+// ensureAtomicImport makes sure "sync/atomic" is imported, since
+// ApplyAtomicFix's rewritten declaration and Add calls need it and the
+// result must build as-is. It extends an existing import block or
+// single-import line if one is present, or inserts a new import
+// declaration after the package clause otherwise.
+func ensureAtomicImport(lines []string) []string {
+	const importPath = `"sync/atomic"`
+
+	for _, l := range lines {
+		if strings.TrimSpace(l) == importPath {
+			return lines
+		}
+	}
+
+	for i, l := range lines {
+		trimmed := strings.TrimSpace(l)
+		if trimmed == "import (" {
+			j := i + 1
+			for j < len(lines) && strings.TrimSpace(lines[j]) < importPath && strings.TrimSpace(lines[j]) != ")" {
+				j++
+			}
+			out := append([]string{}, lines[:j]...)
+			out = append(out, "\t"+importPath)
+			out = append(out, lines[j:]...)
+			return out
+		}
+		if strings.HasPrefix(trimmed, "import \"") {
+			existing := strings.TrimPrefix(trimmed, "import ")
+			first, second := existing, importPath
+			if second < first {
+				first, second = second, first
+			}
+			out := append([]string{}, lines[:i]...)
+			out = append(out, "import (", "\t"+first, "\t"+second, ")")
+			out = append(out, lines[i+1:]...)
+			return out
+		}
+	}
+
+	for i, l := range lines {
+		if strings.HasPrefix(strings.TrimSpace(l), "package ") {
+			out := append([]string{}, lines[:i+1]...)
+			out = append(out, "", "import "+importPath)
+			out = append(out, lines[i+1:]...)
+			return out
+		}
+	}
+	return lines
+}
+
+func readLines(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
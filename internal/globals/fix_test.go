@@ -0,0 +1,47 @@
+package globals
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestApplyAtomicFixAddsImport(t *testing.T) {
+	src, err := os.ReadFile("../../tests/fixtures/sample.go")
+	if err != nil {
+		t.Fatalf("reading fixture: %v", err)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sample.go")
+	if err := os.WriteFile(path, src, 0o644); err != nil {
+		t.Fatalf("writing temp copy: %v", err)
+	}
+
+	if err := ApplyAtomicFix(path, "globalCounter"); err != nil {
+		t.Fatalf("ApplyAtomicFix: %v", err)
+	}
+
+	out, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading fixed file: %v", err)
+	}
+
+	if !strings.Contains(string(out), `"sync/atomic"`) {
+		t.Fatal("expected sync/atomic to be added to the import block")
+	}
+	if !strings.Contains(string(out), "var globalCounter atomic.Int64") {
+		t.Errorf("expected globalCounter's declaration to become atomic.Int64, got:\n%s", out)
+	}
+	if !strings.Contains(string(out), "globalCounter.Add(1)") {
+		t.Errorf("expected globalCounter++ to become globalCounter.Add(1), got:\n%s", out)
+	}
+
+	fset := token.NewFileSet()
+	if _, err := parser.ParseFile(fset, path, out, parser.ParseComments); err != nil {
+		t.Fatalf("fixed file is not valid Go: %v", err)
+	}
+}
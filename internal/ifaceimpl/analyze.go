@@ -0,0 +1,18 @@
+package ifaceimpl
+
+// Analyze loads every package under root, using the module path declared
+// in root's own go.mod, and resolves the implementers of the named
+// interface.
+func Analyze(root, interfaceName string) (*Report, error) {
+	modulePath, err := ModulePath(root)
+	if err != nil {
+		return nil, err
+	}
+
+	loader := NewLoader(root, modulePath)
+	pkgs, err := loader.LoadAll()
+	if err != nil {
+		return nil, err
+	}
+	return Resolve(pkgs, loader.FileSet(), interfaceName)
+}
@@ -0,0 +1,79 @@
+package ifaceimpl
+
+import "testing"
+
+func TestAnalyzeSampleValidatable(t *testing.T) {
+	report, err := Analyze("../../tests/fixtures", "Validatable")
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+
+	found := false
+	for _, impl := range report.Implementers {
+		if impl.Name == "User" {
+			found = true
+			if !impl.PointerRecv {
+				t.Errorf("expected User to implement Validatable via pointer receiver")
+			}
+		}
+	}
+	if !found {
+		t.Fatal("expected User to be resolved as an implementer of Validatable")
+	}
+
+	if len(report.MethodImpacts) != 2 {
+		t.Fatalf("expected 2 method impacts (IsValid, Validate), got %d", len(report.MethodImpacts))
+	}
+}
+
+func TestAnalyzeRequiresGoMod(t *testing.T) {
+	if _, err := Analyze(t.TempDir(), "Validatable"); err == nil {
+		t.Fatal("expected an error analyzing a directory with no go.mod")
+	}
+}
+
+// TestAnalyzeIndirectCallSite covers the common shape where an
+// interface is consumed one level of indirection away from main (main
+// calls a plain function, which calls the interface method), and where
+// a concrete implementer reaches the interface by being passed as a
+// call argument rather than through an assignment or type assertion.
+func TestAnalyzeIndirectCallSite(t *testing.T) {
+	report, err := Analyze("testdata/indirect", "Greeter")
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+
+	if len(report.CallSites) != 1 || report.CallSites[0].Method != "Greet" {
+		t.Fatalf("expected one Greet call site reached transitively through run, got %+v", report.CallSites)
+	}
+
+	var impact *MethodImpact
+	for i := range report.MethodImpacts {
+		if report.MethodImpacts[i].Method == "Greet" {
+			impact = &report.MethodImpacts[i]
+		}
+	}
+	if impact == nil || len(impact.BreaksCallSites) != 1 {
+		t.Fatalf("expected removing Greet to break the call site inside run, got %+v", impact)
+	}
+
+	var english *Implementer
+	for i := range report.Implementers {
+		if report.Implementers[i].Name == "English" {
+			english = &report.Implementers[i]
+		}
+	}
+	if english == nil {
+		t.Fatal("expected English to be resolved as an implementer of Greeter")
+	}
+
+	foundArg := false
+	for _, u := range english.UsageSites {
+		if u.Kind == "argument" {
+			foundArg = true
+		}
+	}
+	if !foundArg {
+		t.Errorf("expected an 'argument' usage site for English{} passed into run(g Greeter), got %+v", english.UsageSites)
+	}
+}
@@ -0,0 +1,214 @@
+// Package ifaceimpl resolves which concrete types implement a given Go
+// interface across a module, and reports where each implementer is used
+// as that interface.
+//
+// It intentionally avoids golang.org/x/tools/go/packages: codescope-mcp
+// only needs to type-check the local module's own packages (no build
+// constraints, no cgo), so a small directory-based loader built on
+// go/types is enough and keeps the tool dependency-free.
+package ifaceimpl
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Package is one type-checked package loaded from a directory.
+type Package struct {
+	Dir        string
+	ImportPath string
+	Files      []*ast.File
+	Info       *types.Info
+	Types      *types.Package
+	// Errors holds type-checking errors collected for this package.
+	// Type-checking continues past them (go/types still produces partial
+	// Info for everything it could resolve), but a non-empty Errors means
+	// implementer/usage-site results for this package may be incomplete
+	// rather than wrong by omission.
+	Errors []string
+}
+
+// Loader type-checks every package under Root on demand, resolving
+// module-local imports back to directories and falling back to the
+// standard library importer for everything else.
+type Loader struct {
+	Root       string
+	ModulePath string
+
+	fset    *token.FileSet
+	pkgs    map[string]*Package
+	loading map[string]bool
+	std     types.Importer
+}
+
+// NewLoader creates a Loader rooted at root, using modulePath as the
+// prefix that identifies module-local import paths (e.g.
+// "codescope-mcp").
+func NewLoader(root, modulePath string) *Loader {
+	return &Loader{
+		Root:       root,
+		ModulePath: modulePath,
+		fset:       token.NewFileSet(),
+		pkgs:       make(map[string]*Package),
+		loading:    make(map[string]bool),
+		std:        importer.Default(),
+	}
+}
+
+// FileSet returns the token.FileSet shared by every loaded package, for
+// resolving positions.
+func (l *Loader) FileSet() *token.FileSet { return l.fset }
+
+// ModulePath reads the `module` directive from root/go.mod. Loading a
+// target module always requires this: without the target's own import
+// path, isLocal/dirForImportPath cannot tell the target's own
+// intra-module imports apart from third-party ones, and would silently
+// route them to the stdlib importer, which produces wrong type
+// information rather than failing loudly.
+func ModulePath(root string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return "", fmt.Errorf("ifaceimpl: reading go.mod in %s: %w", root, err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "module ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "module")), nil
+		}
+	}
+	return "", fmt.Errorf("ifaceimpl: go.mod in %s has no module directive", root)
+}
+
+// LoadAll type-checks every package directory under l.Root and returns
+// them in a stable order, sorted by import path.
+func (l *Loader) LoadAll() ([]*Package, error) {
+	dirs, err := packageDirs(l.Root)
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range dirs {
+		importPath := l.importPathForDir(dir)
+		if _, err := l.Import(importPath); err != nil {
+			return nil, fmt.Errorf("ifaceimpl: loading %s: %w", importPath, err)
+		}
+	}
+
+	var out []*Package
+	for _, p := range l.pkgs {
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ImportPath < out[j].ImportPath })
+	return out, nil
+}
+
+// Import implements types.Importer, loading and type-checking the
+// module-local package at path on first use, or delegating to the
+// standard-library importer otherwise.
+func (l *Loader) Import(path string) (*types.Package, error) {
+	if pkg, ok := l.pkgs[path]; ok {
+		return pkg.Types, nil
+	}
+	if !l.isLocal(path) {
+		return l.std.Import(path)
+	}
+	if l.loading[path] {
+		return nil, fmt.Errorf("ifaceimpl: import cycle detected at %s", path)
+	}
+	l.loading[path] = true
+	defer delete(l.loading, path)
+
+	dir := l.dirForImportPath(path)
+	files, err := parseDir(l.fset, dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("ifaceimpl: no Go files in %s", dir)
+	}
+
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	var typeErrors []string
+	cfg := &types.Config{Importer: l, Error: func(err error) {
+		// Best-effort: keep going so the caller still gets partial Info,
+		// but record the error instead of swallowing it outright.
+		typeErrors = append(typeErrors, err.Error())
+	}}
+	typesPkg, _ := cfg.Check(path, l.fset, files, info)
+
+	pkg := &Package{Dir: dir, ImportPath: path, Files: files, Info: info, Types: typesPkg, Errors: typeErrors}
+	l.pkgs[path] = pkg
+	return typesPkg, nil
+}
+
+func (l *Loader) isLocal(path string) bool {
+	return path == l.ModulePath || strings.HasPrefix(path, l.ModulePath+"/")
+}
+
+func (l *Loader) dirForImportPath(path string) string {
+	if path == l.ModulePath {
+		return l.Root
+	}
+	rel := strings.TrimPrefix(path, l.ModulePath+"/")
+	return filepath.Join(l.Root, filepath.FromSlash(rel))
+}
+
+func (l *Loader) importPathForDir(dir string) string {
+	rel, err := filepath.Rel(l.Root, dir)
+	if err != nil || rel == "." {
+		return l.ModulePath
+	}
+	return l.ModulePath + "/" + filepath.ToSlash(rel)
+}
+
+func packageDirs(root string) ([]string, error) {
+	seen := make(map[string]bool)
+	var dirs []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		dir := filepath.Dir(path)
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+		return nil
+	})
+	sort.Strings(dirs)
+	return dirs, err
+}
+
+func parseDir(fset *token.FileSet, dir string) ([]*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []*ast.File
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".go") || strings.HasSuffix(e.Name(), "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, e.Name()), nil, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", e.Name(), err)
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
@@ -0,0 +1,406 @@
+package ifaceimpl
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+)
+
+// Position is a JSON-friendly source position.
+type Position struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+	Col  int    `json:"col"`
+}
+
+// UsageSite is a place where a concrete implementer is used through the
+// interface: an assertion/conversion, or an assignment/argument whose
+// static interface-typed target receives the concrete value.
+type UsageSite struct {
+	Kind string   `json:"kind"` // "assert", "conversion", "assign", or "argument"
+	Pos  Position `json:"pos"`
+	Expr string   `json:"expr"`
+}
+
+// MethodCallSite is a call to one interface method, reached through a
+// value whose static type is the interface itself.
+type MethodCallSite struct {
+	Method string   `json:"method"`
+	Pos    Position `json:"pos"`
+}
+
+// Implementer is one concrete type satisfying the resolved interface.
+type Implementer struct {
+	Name        string      `json:"name"`
+	Pos         Position    `json:"pos"`
+	PointerRecv bool        `json:"pointer_receiver"`
+	UsageSites  []UsageSite `json:"usage_sites"`
+}
+
+// MethodImpact describes what would happen if a method were removed
+// from the interface. Because every implementer by definition already
+// has all interface methods as concrete methods, removing a method from
+// the interface never breaks an implementer's method set — what it can
+// break is code that calls the method through a variable statically
+// typed as the interface. BreaksCallSites lists exactly that code.
+type MethodImpact struct {
+	Method          string           `json:"method"`
+	BreaksCallSites []MethodCallSite `json:"breaks_call_sites"`
+}
+
+// Report is the full result for one interface.
+type Report struct {
+	Interface     string           `json:"interface"`
+	Implementers  []Implementer    `json:"implementers"`
+	CallSites     []MethodCallSite `json:"call_sites_from_main"`
+	MethodImpacts []MethodImpact   `json:"method_impacts"`
+	// Warnings surfaces type-checking errors collected while loading the
+	// module (see Package.Errors): results are still best-effort in their
+	// presence, not silently treated as complete.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Resolve finds ifaceName (a bare type name, e.g. "Validatable") among
+// the loaded packages, then resolves its implementers and usage sites.
+func Resolve(pkgs []*Package, fset *token.FileSet, ifaceName string) (*Report, error) {
+	ifaceType, ifaceIface, err := findInterface(pkgs, ifaceName)
+	if err != nil {
+		return nil, err
+	}
+
+	implementers := findImplementers(pkgs, fset, ifaceIface)
+	for i := range implementers {
+		implementers[i].UsageSites = findUsageSites(pkgs, fset, ifaceType, implementers[i].Name)
+	}
+
+	callSites := findCallSitesFromMain(pkgs, fset, ifaceType, ifaceIface)
+
+	var impacts []MethodImpact
+	for i := 0; i < ifaceIface.NumMethods(); i++ {
+		m := ifaceIface.Method(i)
+		var breaking []MethodCallSite
+		for _, cs := range callSites {
+			if cs.Method == m.Name() {
+				breaking = append(breaking, cs)
+			}
+		}
+		impacts = append(impacts, MethodImpact{Method: m.Name(), BreaksCallSites: breaking})
+	}
+
+	var warnings []string
+	for _, pkg := range pkgs {
+		warnings = append(warnings, pkg.Errors...)
+	}
+
+	return &Report{
+		Interface:     ifaceName,
+		Implementers:  implementers,
+		CallSites:     callSites,
+		MethodImpacts: impacts,
+		Warnings:      warnings,
+	}, nil
+}
+
+func findInterface(pkgs []*Package, name string) (types.Type, *types.Interface, error) {
+	for _, pkg := range pkgs {
+		if pkg.Types == nil {
+			continue
+		}
+		obj := pkg.Types.Scope().Lookup(name)
+		if obj == nil {
+			continue
+		}
+		tn, ok := obj.(*types.TypeName)
+		if !ok {
+			continue
+		}
+		iface, ok := tn.Type().Underlying().(*types.Interface)
+		if !ok {
+			continue
+		}
+		return tn.Type(), iface, nil
+	}
+	return nil, nil, fmt.Errorf("ifaceimpl: interface %q not found in loaded packages", name)
+}
+
+// findImplementers scans every named type declared across pkgs and
+// keeps those whose method set (value or pointer receiver) satisfies
+// iface.
+func findImplementers(pkgs []*Package, fset *token.FileSet, iface *types.Interface) []Implementer {
+	var out []Implementer
+	for _, pkg := range pkgs {
+		if pkg.Types == nil {
+			continue
+		}
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			obj := scope.Lookup(name)
+			tn, ok := obj.(*types.TypeName)
+			if !ok {
+				continue
+			}
+			named, ok := tn.Type().(*types.Named)
+			if !ok {
+				continue
+			}
+			if _, isIface := named.Underlying().(*types.Interface); isIface {
+				continue
+			}
+
+			valueOK := types.Implements(named, iface)
+			ptrOK := types.Implements(types.NewPointer(named), iface)
+			if !valueOK && !ptrOK {
+				continue
+			}
+			out = append(out, Implementer{
+				Name:        name,
+				Pos:         toPosition(fset, tn.Pos()),
+				PointerRecv: ptrOK && !valueOK,
+			})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// findUsageSites looks for type assertions from an ifaceType value to
+// the concrete implementer, conversions of an implementer value to
+// ifaceType, and assignments/call arguments where an expression of the
+// concrete implementer type flows into a variable, field, or parameter
+// whose static type is the interface.
+func findUsageSites(pkgs []*Package, fset *token.FileSet, ifaceType types.Type, implName string) []UsageSite {
+	var sites []UsageSite
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			ast.Inspect(file, func(n ast.Node) bool {
+				switch e := n.(type) {
+				case *ast.TypeAssertExpr:
+					if e.Type == nil {
+						return true
+					}
+					if xType := pkg.Info.TypeOf(e.X); xType != nil && types.Identical(xType, ifaceType) {
+						assertedType := pkg.Info.TypeOf(e.Type)
+						if assertedType != nil && namedTypeName(assertedType) == implName {
+							sites = append(sites, UsageSite{Kind: "assert", Pos: toPosition(fset, e.Pos()), Expr: exprString(e.X) + ".(" + implName + ")"})
+						}
+					}
+				case *ast.AssignStmt:
+					for i, rhs := range e.Rhs {
+						if i >= len(e.Lhs) {
+							continue
+						}
+						lhsType := pkg.Info.TypeOf(e.Lhs[i])
+						rhsType := pkg.Info.TypeOf(rhs)
+						if lhsType == nil || rhsType == nil {
+							continue
+						}
+						if types.Identical(lhsType, ifaceType) && namedTypeName(rhsType) == implName {
+							sites = append(sites, UsageSite{Kind: "assign", Pos: toPosition(fset, rhs.Pos()), Expr: exprString(rhs)})
+						}
+					}
+				case *ast.CallExpr:
+					if tv, ok := pkg.Info.Types[e.Fun]; ok && tv.IsType() {
+						if types.Identical(tv.Type, ifaceType) && len(e.Args) == 1 {
+							if argType := pkg.Info.TypeOf(e.Args[0]); argType != nil && namedTypeName(argType) == implName {
+								sites = append(sites, UsageSite{Kind: "conversion", Pos: toPosition(fset, e.Pos()), Expr: exprString(e)})
+							}
+						}
+						return true
+					}
+					sig, ok := pkg.Info.TypeOf(e.Fun).(*types.Signature)
+					if !ok {
+						return true
+					}
+					for i, arg := range e.Args {
+						paramType := paramTypeAt(sig, i)
+						if paramType == nil || !types.Identical(paramType, ifaceType) {
+							continue
+						}
+						argType := pkg.Info.TypeOf(arg)
+						if argType != nil && namedTypeName(argType) == implName {
+							sites = append(sites, UsageSite{Kind: "argument", Pos: toPosition(fset, arg.Pos()), Expr: exprString(arg)})
+						}
+					}
+				}
+				return true
+			})
+		}
+	}
+	return sites
+}
+
+// funcNode is a plain (non-method) function reachable in the call
+// graph, paired with the package it was loaded from so its body can be
+// re-inspected with that package's own *types.Info.
+type funcNode struct {
+	decl *ast.FuncDecl
+	pkg  *Package
+}
+
+// collectFuncNodes indexes every plain top-level function across pkgs
+// by its *types.Object, so a call site naming it (by identifier, or by
+// package-qualified selector) can be resolved back to a body to walk
+// into. Methods are excluded, mirroring internal/globals's call graph,
+// which also only traces plain function calls.
+func collectFuncNodes(pkgs []*Package) map[types.Object]funcNode {
+	out := make(map[types.Object]funcNode)
+	for _, pkg := range pkgs {
+		if pkg.Info == nil {
+			continue
+		}
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Recv != nil {
+					continue
+				}
+				if obj, ok := pkg.Info.Defs[fn.Name]; ok && obj != nil {
+					out[obj] = funcNode{decl: fn, pkg: pkg}
+				}
+			}
+		}
+	}
+	return out
+}
+
+// calleeObject resolves a call expression's Fun to the *types.Object it
+// invokes: the identifier itself for a bare call, or the selected name
+// for a package-qualified one (`pkg.Func(...)`). Method calls resolve
+// too, but collectFuncNodes never indexes them, so they're simply not
+// found as traversable nodes.
+func calleeObject(fun ast.Expr, pkg *Package) types.Object {
+	switch e := fun.(type) {
+	case *ast.Ident:
+		return pkg.Info.Uses[e]
+	case *ast.SelectorExpr:
+		return pkg.Info.Uses[e.Sel]
+	}
+	return nil
+}
+
+// findCallSitesFromMain performs a worklist walk of the call graph
+// transitively reachable from every func main across pkgs (plain
+// function calls only, same as internal/globals's reachability walk),
+// and records, at every function visited along the way, calls to iface
+// methods made through a receiver whose static type is the interface
+// itself. Without this transitive walk, an interface consumed one level
+// of indirection away from main (the common `func run(g Greeter) {
+// g.Greet() }` / `func main() { run(English{}) }` shape) would be
+// missed entirely.
+func findCallSitesFromMain(pkgs []*Package, fset *token.FileSet, ifaceType types.Type, iface *types.Interface) []MethodCallSite {
+	methodNames := make(map[string]bool)
+	for i := 0; i < iface.NumMethods(); i++ {
+		methodNames[iface.Method(i).Name()] = true
+	}
+
+	funcNodes := collectFuncNodes(pkgs)
+	visited := make(map[types.Object]bool)
+	var queue []funcNode
+
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Name.Name != "main" || fn.Recv != nil {
+					continue
+				}
+				obj, ok := pkg.Info.Defs[fn.Name]
+				if !ok || obj == nil || visited[obj] {
+					continue
+				}
+				visited[obj] = true
+				queue = append(queue, funcNode{decl: fn, pkg: pkg})
+			}
+		}
+	}
+
+	var sites []MethodCallSite
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		ast.Inspect(node.decl, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && methodNames[sel.Sel.Name] {
+				if recvType := node.pkg.Info.TypeOf(sel.X); recvType != nil && types.Identical(recvType, ifaceType) {
+					sites = append(sites, MethodCallSite{Method: sel.Sel.Name, Pos: toPosition(fset, call.Pos())})
+				}
+			}
+
+			if obj := calleeObject(call.Fun, node.pkg); obj != nil && !visited[obj] {
+				if callee, ok := funcNodes[obj]; ok {
+					visited[obj] = true
+					queue = append(queue, callee)
+				}
+			}
+			return true
+		})
+	}
+
+	sort.Slice(sites, func(i, j int) bool {
+		if sites[i].Pos.File != sites[j].Pos.File {
+			return sites[i].Pos.File < sites[j].Pos.File
+		}
+		return sites[i].Pos.Line < sites[j].Pos.Line
+	})
+	return sites
+}
+
+// paramTypeAt returns the static type of sig's parameter that argument
+// index i binds to, following a variadic trailing parameter (`...T`)
+// out to its element type for every index at or past it. Returns nil
+// for an index past a non-variadic signature's parameter list.
+func paramTypeAt(sig *types.Signature, i int) types.Type {
+	params := sig.Params()
+	n := params.Len()
+	if n == 0 {
+		return nil
+	}
+	if sig.Variadic() && i >= n-1 {
+		if slice, ok := params.At(n - 1).Type().(*types.Slice); ok {
+			return slice.Elem()
+		}
+		return nil
+	}
+	if i >= n {
+		return nil
+	}
+	return params.At(i).Type()
+}
+
+func namedTypeName(t types.Type) string {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok {
+		return ""
+	}
+	return named.Obj().Name()
+}
+
+func toPosition(fset *token.FileSet, pos token.Pos) Position {
+	p := fset.Position(pos)
+	return Position{File: p.Filename, Line: p.Line, Col: p.Column}
+}
+
+func exprString(e ast.Expr) string {
+	switch v := e.(type) {
+	case *ast.Ident:
+		return v.Name
+	case *ast.SelectorExpr:
+		return exprString(v.X) + "." + v.Sel.Name
+	case *ast.UnaryExpr:
+		return v.Op.String() + exprString(v.X)
+	case *ast.CallExpr:
+		return exprString(v.Fun) + "(...)"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,23 @@
+package main
+
+// Greeter is satisfied implicitly by English below.
+type Greeter interface {
+	Greet() string
+}
+
+// English is the only concrete implementer of Greeter in this fixture.
+type English struct{}
+
+func (English) Greet() string {
+	return "hello"
+}
+
+// run is the one level of indirection between main and the interface
+// method call: main never calls Greet itself.
+func run(g Greeter) {
+	g.Greet()
+}
+
+func main() {
+	run(English{})
+}
@@ -0,0 +1,186 @@
+// Package mcp implements a minimal Model Context Protocol server over
+// stdio JSON-RPC. It is intentionally small: codescope-mcp only needs to
+// advertise a handful of analysis tools and dispatch tool calls, not the
+// full MCP surface (resources, prompts, sampling, ...).
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Tool is a single MCP tool exposed by the server. Handler receives the
+// raw "arguments" object from a tools/call request and returns a result
+// that is JSON-marshaled back to the client.
+type Tool struct {
+	Name        string
+	Description string
+	InputSchema map[string]any
+	Handler     func(ctx context.Context, args json.RawMessage) (any, error)
+}
+
+// Server dispatches JSON-RPC 2.0 requests to registered tools.
+type Server struct {
+	Name    string
+	Version string
+
+	mu    sync.Mutex
+	tools map[string]Tool
+	order []string
+}
+
+// NewServer creates a Server with no tools registered.
+func NewServer(name, version string) *Server {
+	return &Server{
+		Name:    name,
+		Version: version,
+		tools:   make(map[string]Tool),
+	}
+}
+
+// RegisterTool adds a tool to the registry. It panics if a tool with the
+// same name is already registered, since that indicates a programming
+// error in the server wiring rather than a recoverable runtime condition.
+func (s *Server) RegisterTool(t Tool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.tools[t.Name]; exists {
+		panic(fmt.Sprintf("mcp: tool %q already registered", t.Name))
+	}
+	s.tools[t.Name] = t
+	s.order = append(s.order, t.Name)
+}
+
+// Tools returns the registered tools in registration order.
+func (s *Server) Tools() []Tool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]Tool, len(s.order))
+	for i, name := range s.order {
+		out[i] = s.tools[name]
+	}
+	return out
+}
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type toolCallParams struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments"`
+}
+
+// Serve reads newline-delimited JSON-RPC requests from r and writes
+// responses to w until r is exhausted or ctx is canceled.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	enc := json.NewEncoder(w)
+
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var req rpcRequest
+		if err := json.Unmarshal(line, &req); err != nil {
+			_ = enc.Encode(rpcResponse{JSONRPC: "2.0", Error: &rpcError{Code: -32700, Message: "parse error: " + err.Error()}})
+			continue
+		}
+		resp := s.handle(ctx, req)
+		if resp == nil {
+			continue
+		}
+		if err := enc.Encode(resp); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func (s *Server) handle(ctx context.Context, req rpcRequest) *rpcResponse {
+	switch req.Method {
+	case "initialize":
+		return &rpcResponse{JSONRPC: "2.0", ID: req.ID, Result: map[string]any{
+			"protocolVersion": "2024-11-05",
+			"serverInfo":      map[string]string{"name": s.Name, "version": s.Version},
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+		}}
+	case "notifications/initialized":
+		return nil
+	case "tools/list":
+		return &rpcResponse{JSONRPC: "2.0", ID: req.ID, Result: map[string]any{"tools": s.listTools()}}
+	case "tools/call":
+		return s.handleToolCall(ctx, req)
+	default:
+		return &rpcResponse{JSONRPC: "2.0", ID: req.ID, Error: &rpcError{Code: -32601, Message: "method not found: " + req.Method}}
+	}
+}
+
+func (s *Server) listTools() []map[string]any {
+	tools := s.Tools()
+	out := make([]map[string]any, len(tools))
+	for i, t := range tools {
+		out[i] = map[string]any{
+			"name":        t.Name,
+			"description": t.Description,
+			"inputSchema": t.InputSchema,
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i]["name"].(string) < out[j]["name"].(string) })
+	return out
+}
+
+func (s *Server) handleToolCall(ctx context.Context, req rpcRequest) *rpcResponse {
+	var params toolCallParams
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		return &rpcResponse{JSONRPC: "2.0", ID: req.ID, Error: &rpcError{Code: -32602, Message: "invalid params: " + err.Error()}}
+	}
+
+	s.mu.Lock()
+	tool, ok := s.tools[params.Name]
+	s.mu.Unlock()
+	if !ok {
+		return &rpcResponse{JSONRPC: "2.0", ID: req.ID, Error: &rpcError{Code: -32602, Message: "unknown tool: " + params.Name}}
+	}
+
+	result, err := tool.Handler(ctx, params.Arguments)
+	if err != nil {
+		return &rpcResponse{JSONRPC: "2.0", ID: req.ID, Error: &rpcError{Code: -32000, Message: err.Error()}}
+	}
+	return &rpcResponse{JSONRPC: "2.0", ID: req.ID, Result: map[string]any{
+		"content": []map[string]any{{"type": "text", "text": mustJSON(result)}},
+	}}
+}
+
+func mustJSON(v any) string {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("{\"error\": %q}", err.Error())
+	}
+	return string(b)
+}
@@ -0,0 +1,55 @@
+package todoscan
+
+import (
+	"bufio"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// enrichWithBlame fills in Author, Commit, Date, and StaleDays for
+// marker by shelling out to `git blame --porcelain` on its line. It is
+// a no-op (leaves the fields empty) when repoRoot is not a git working
+// tree or the blame lookup fails, since TODO markers are still useful
+// without provenance.
+func enrichWithBlame(marker *Marker, repoRoot string) {
+	rel, err := filepath.Rel(repoRoot, marker.File)
+	if err != nil {
+		rel = marker.File
+	}
+
+	line := strconv.Itoa(marker.Line)
+	cmd := exec.Command("git", "blame", "-L", line+","+line, "--porcelain", "--", rel)
+	cmd.Dir = repoRoot
+	out, err := cmd.Output()
+	if err != nil {
+		return
+	}
+
+	var author, dateStr, commit string
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case commit == "" && len(strings.Fields(line)) > 0 && len(strings.Fields(line)[0]) == 40:
+			commit = strings.Fields(line)[0]
+		case strings.HasPrefix(line, "author "):
+			author = strings.TrimPrefix(line, "author ")
+		case strings.HasPrefix(line, "author-time "):
+			if sec, err := strconv.ParseInt(strings.TrimPrefix(line, "author-time "), 10, 64); err == nil {
+				dateStr = time.Unix(sec, 0).UTC().Format(time.RFC3339)
+			}
+		}
+	}
+
+	marker.Author = author
+	marker.Commit = commit
+	if dateStr != "" {
+		if t, err := time.Parse(time.RFC3339, dateStr); err == nil {
+			marker.Date = t
+			marker.StaleDays = int(time.Since(t).Hours() / 24)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package todoscan
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ConvertMode selects the replacement shape for ConvertMarker.
+type ConvertMode string
+
+const (
+	// ModePragma rewrites the marker into a machine-readable //go:
+	// directive comment, e.g. "//go:todo(FIXME) handle edge cases".
+	ModePragma ConvertMode = "pragma"
+	// ModeIssueStub rewrites the marker into a reference to an external
+	// issue tracker entry, e.g. "// See ISSUE-123: handle edge cases".
+	ModeIssueStub ConvertMode = "issue_stub"
+)
+
+// ConvertResult is the outcome of converting one marker.
+type ConvertResult struct {
+	File  string `json:"file"`
+	Line  int    `json:"line"`
+	Patch string `json:"patch"`
+}
+
+// ConvertMarker rewrites the comment line containing marker into the
+// requested form and returns a unified diff hunk. issueID is used only
+// for ModeIssueStub.
+func ConvertMarker(marker Marker, mode ConvertMode, issueID string) (*ConvertResult, error) {
+	lines, err := readLines(marker.File)
+	if err != nil {
+		return nil, fmt.Errorf("todoscan: read %s: %w", marker.File, err)
+	}
+	if marker.Line < 1 || marker.Line > len(lines) {
+		return nil, fmt.Errorf("todoscan: line %d out of range in %s", marker.Line, marker.File)
+	}
+
+	original := lines[marker.Line-1]
+	indent := original[:len(original)-len(strings.TrimLeft(original, " \t"))]
+
+	var replacement string
+	switch mode {
+	case ModePragma:
+		replacement = fmt.Sprintf("%s//go:todo(%s) %s", indent, marker.Kind, marker.Text)
+	case ModeIssueStub:
+		if issueID == "" {
+			issueID = "TBD"
+		}
+		replacement = fmt.Sprintf("%s// See %s: %s", indent, issueID, marker.Text)
+	default:
+		return nil, fmt.Errorf("todoscan: unknown convert mode %q", mode)
+	}
+
+	patch := fmt.Sprintf(
+		"--- a/%s\n+++ b/%s\n@@ -%d,1 +%d,1 @@\n-%s\n+%s\n",
+		marker.File, marker.File, marker.Line, marker.Line, original, replacement,
+	)
+
+	return &ConvertResult{File: marker.File, Line: marker.Line, Patch: patch}, nil
+}
+
+func readLines(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
@@ -0,0 +1,81 @@
+package todoscan
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConvertMarkerPragma(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sample.go")
+	src := "package sample\n\n// TODO: Add more validation rules\nfunc Bar() {}\n"
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	marker := Marker{File: path, Line: 3, Kind: "TODO", Text: "Add more validation rules"}
+	result, err := ConvertMarker(marker, ModePragma, "")
+	if err != nil {
+		t.Fatalf("ConvertMarker: %v", err)
+	}
+
+	if result.File != path || result.Line != 3 {
+		t.Errorf("result file/line = %q/%d, want %q/%d", result.File, result.Line, path, 3)
+	}
+	if !strings.Contains(result.Patch, "+//go:todo(TODO) Add more validation rules") {
+		t.Errorf("expected patch to add a //go:todo pragma, got:\n%s", result.Patch)
+	}
+	if !strings.Contains(result.Patch, "-// TODO: Add more validation rules") {
+		t.Errorf("expected patch to remove the original TODO comment, got:\n%s", result.Patch)
+	}
+}
+
+func TestConvertMarkerIssueStub(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sample.go")
+	src := "package sample\n\n\t// FIXME: Handle edge cases for empty strings\nfunc Bar() {}\n"
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	marker := Marker{File: path, Line: 3, Kind: "FIXME", Text: "Handle edge cases for empty strings"}
+	result, err := ConvertMarker(marker, ModeIssueStub, "ISSUE-42")
+	if err != nil {
+		t.Fatalf("ConvertMarker: %v", err)
+	}
+	if !strings.Contains(result.Patch, "+\t// See ISSUE-42: Handle edge cases for empty strings") {
+		t.Errorf("expected patch to reference ISSUE-42 with the original indent preserved, got:\n%s", result.Patch)
+	}
+
+	if _, err := ConvertMarker(marker, ModeIssueStub, ""); err != nil {
+		t.Fatalf("ConvertMarker with no issueID: %v", err)
+	}
+}
+
+func TestConvertMarkerUnknownMode(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sample.go")
+	if err := os.WriteFile(path, []byte("package sample\n"), 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	marker := Marker{File: path, Line: 1, Kind: "TODO", Text: "x"}
+	if _, err := ConvertMarker(marker, ConvertMode("bogus"), ""); err == nil {
+		t.Fatal("expected an error for an unknown convert mode")
+	}
+}
+
+func TestConvertMarkerLineOutOfRange(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sample.go")
+	if err := os.WriteFile(path, []byte("package sample\n"), 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	marker := Marker{File: path, Line: 99, Kind: "TODO", Text: "x"}
+	if _, err := ConvertMarker(marker, ModePragma, ""); err == nil {
+		t.Fatal("expected an error for a line out of range")
+	}
+}
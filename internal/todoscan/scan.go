@@ -0,0 +1,263 @@
+// Package todoscan extracts TODO/FIXME/HACK/NOTE markers from Go
+// comments, enriches them with git blame metadata, and infers a
+// severity for each.
+package todoscan
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultMarkers is the marker set scanned when none is supplied.
+var DefaultMarkers = []string{"FIXME", "TODO", "HACK", "NOTE"}
+
+var severityRank = map[string]int{"FIXME": 3, "HACK": 3, "TODO": 2, "NOTE": 1}
+
+// Marker is one comment marker found in the source.
+type Marker struct {
+	File          string    `json:"file"`
+	Line          int       `json:"line"`
+	Kind          string    `json:"kind"`
+	Text          string    `json:"text"`
+	EnclosingDecl string    `json:"enclosing_decl,omitempty"`
+	Severity      string    `json:"severity"`
+	Author        string    `json:"author,omitempty"`
+	Commit        string    `json:"commit,omitempty"`
+	Date          time.Time `json:"date,omitempty"`
+	StaleDays     int       `json:"stale_days,omitempty"`
+}
+
+// ScanOptions configures ScanFile/ScanDir.
+type ScanOptions struct {
+	Markers []string
+	// RepoRoot, when set, enables git blame enrichment via `git blame`
+	// run with that directory as the working directory.
+	RepoRoot string
+}
+
+func (o ScanOptions) markerSet() map[string]bool {
+	markers := o.Markers
+	if len(markers) == 0 {
+		markers = DefaultMarkers
+	}
+	set := make(map[string]bool, len(markers))
+	for _, m := range markers {
+		set[strings.ToUpper(m)] = true
+	}
+	return set
+}
+
+// ScanFile extracts markers from a single Go file.
+func ScanFile(path string, opts ScanOptions) ([]Marker, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("todoscan: parse %s: %w", path, err)
+	}
+
+	allowed := opts.markerSet()
+	names := make([]string, 0, len(allowed))
+	for m := range allowed {
+		names = append(names, regexp.QuoteMeta(m))
+	}
+	sort.Strings(names)
+	markerLine := regexp.MustCompile(`^(` + strings.Join(names, "|") + `)\b:?\s*(.*)$`)
+	cmap := ast.NewCommentMap(fset, file, file.Comments)
+
+	var markers []Marker
+	for _, group := range file.Comments {
+		for _, c := range group.List {
+			text := strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(c.Text, "//"), "/*"))
+			text = strings.TrimSuffix(text, "*/")
+			m := markerLine.FindStringSubmatch(text)
+			if m == nil || !allowed[m[1]] {
+				continue
+			}
+			marker := Marker{
+				File:     path,
+				Line:     fset.Position(c.Pos()).Line,
+				Kind:     m[1],
+				Text:     strings.TrimSpace(m[2]),
+				Severity: severityFor(m[1], cmap, group, file),
+			}
+			if opts.RepoRoot != "" {
+				enrichWithBlame(&marker, opts.RepoRoot)
+			}
+			markers = append(markers, marker)
+		}
+	}
+
+	for i := range markers {
+		markers[i].EnclosingDecl = enclosingDeclName(fset, file, markers[i].Line)
+	}
+
+	return markers, nil
+}
+
+// ScanDir recursively scans every .go file under root.
+func ScanDir(root string, opts ScanOptions) ([]Marker, error) {
+	var out []Marker
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		markers, err := ScanFile(path, opts)
+		if err != nil {
+			return err
+		}
+		out = append(out, markers...)
+		return nil
+	})
+	return out, err
+}
+
+// severityFor ranks FIXME > TODO > NOTE (HACK treated like FIXME), and
+// bumps severity when the marker sits in a function whose body panics,
+// returns a bare nil error, or swallows an error with `_ = err`.
+func severityFor(kind string, cmap ast.CommentMap, group *ast.CommentGroup, file *ast.File) string {
+	base := severityRank[kind]
+	if fn := enclosingFunc(cmap, group, file); fn != nil && looksRisky(fn) {
+		base++
+	}
+	switch {
+	case base >= 4:
+		return "critical"
+	case base == 3:
+		return "high"
+	case base == 2:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+func enclosingFunc(cmap ast.CommentMap, group *ast.CommentGroup, file *ast.File) *ast.FuncDecl {
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Pos() <= group.Pos() && group.End() <= fn.End() {
+			return fn
+		}
+	}
+	for node, groups := range cmap {
+		fn, ok := node.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		for _, g := range groups {
+			if g == group {
+				return fn
+			}
+		}
+	}
+	return nil
+}
+
+func looksRisky(fn *ast.FuncDecl) bool {
+	risky := false
+	ast.Inspect(fn, func(n ast.Node) bool {
+		switch e := n.(type) {
+		case *ast.CallExpr:
+			if id, ok := e.Fun.(*ast.Ident); ok && id.Name == "panic" {
+				risky = true
+			}
+		case *ast.ReturnStmt:
+			for _, r := range e.Results {
+				if id, ok := r.(*ast.Ident); ok && id.Name == "nil" {
+					risky = true
+				}
+			}
+		case *ast.AssignStmt:
+			for i, lhs := range e.Lhs {
+				id, ok := lhs.(*ast.Ident)
+				if !ok || id.Name != "_" || i >= len(e.Rhs) {
+					continue
+				}
+				if rhsID, ok := e.Rhs[i].(*ast.Ident); ok && strings.Contains(strings.ToLower(rhsID.Name), "err") {
+					risky = true
+				}
+			}
+		}
+		return true
+	})
+	return risky
+}
+
+// enclosingDeclName returns the name of the top-level FuncDecl or
+// GenDecl (type/const/var) that line belongs to. A line inside a decl's
+// own range is an exact match; a marker comment that sits between two
+// decls instead resolves to whichever is fewer lines away by actual
+// distance, preferring the following decl on a tie (a comment directly
+// above a func with no blank line is that func's doc comment, by Go
+// convention). Returns "" only when the file has no decls at all.
+func enclosingDeclName(fset *token.FileSet, file *ast.File, line int) string {
+	var preceding ast.Decl
+	var precedingEnd int
+	var following ast.Decl
+	var followingStart int
+
+	for _, decl := range file.Decls {
+		start := fset.Position(decl.Pos()).Line
+		end := fset.Position(decl.End()).Line
+
+		if line >= start && line <= end {
+			return declName(decl)
+		}
+		if end < line && (preceding == nil || end > precedingEnd) {
+			preceding, precedingEnd = decl, end
+		}
+		if start > line && (following == nil || start < followingStart) {
+			following, followingStart = decl, start
+		}
+	}
+
+	switch {
+	case preceding == nil:
+		if following != nil {
+			return declName(following)
+		}
+		return ""
+	case following == nil:
+		return declName(preceding)
+	case followingStart-line <= line-precedingEnd:
+		return declName(following)
+	default:
+		return declName(preceding)
+	}
+}
+
+// declName returns the name a FuncDecl or GenDecl (type/const/var)
+// is reported under.
+func declName(decl ast.Decl) string {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		return d.Name.Name
+	case *ast.GenDecl:
+		for _, spec := range d.Specs {
+			if ts, ok := spec.(*ast.TypeSpec); ok {
+				return ts.Name.Name
+			}
+			if vs, ok := spec.(*ast.ValueSpec); ok && len(vs.Names) > 0 {
+				return vs.Names[0].Name
+			}
+		}
+	}
+	return ""
+}
+
+// SortByStaleness sorts markers oldest (most stale) first. Markers with
+// no blame date (StaleDays == 0 and Date zero) sort last.
+func SortByStaleness(markers []Marker) {
+	sort.SliceStable(markers, func(i, j int) bool {
+		return markers[i].StaleDays > markers[j].StaleDays
+	})
+}
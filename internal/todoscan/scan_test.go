@@ -0,0 +1,79 @@
+package todoscan
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScanFileSample(t *testing.T) {
+	markers, err := ScanFile("../../tests/fixtures/sample.go", ScanOptions{})
+	if err != nil {
+		t.Fatalf("ScanFile: %v", err)
+	}
+
+	var kinds []string
+	for _, m := range markers {
+		kinds = append(kinds, m.Kind)
+	}
+	if len(kinds) != 2 || kinds[0] != "TODO" || kinds[1] != "FIXME" {
+		t.Fatalf("expected [TODO FIXME], got %v", kinds)
+	}
+
+	for _, m := range markers {
+		if m.Severity == "" {
+			t.Errorf("marker %+v missing severity", m)
+		}
+		if m.EnclosingDecl == "" {
+			t.Errorf("marker %+v: expected a nearest enclosing decl, got none", m)
+		}
+	}
+
+	// TODO (line 105) sits 2 lines after validateEmail's closing brace
+	// and 3 lines before main's opening line, so its nearest decl by
+	// actual distance is validateEmail. FIXME (line 106) is the
+	// opposite: 3 lines after validateEmail, 2 lines before main, so it
+	// resolves to main instead, even though both markers precede main
+	// and neither sits inside any decl's own range.
+	if markers[0].EnclosingDecl != "validateEmail" {
+		t.Errorf("TODO: EnclosingDecl = %q, want %q (nearer by distance)", markers[0].EnclosingDecl, "validateEmail")
+	}
+	if markers[1].EnclosingDecl != "main" {
+		t.Errorf("FIXME: EnclosingDecl = %q, want %q (nearer by distance)", markers[1].EnclosingDecl, "main")
+	}
+}
+
+// TestEnclosingDeclNameDocComment covers the common case of a marker
+// sitting directly above a func with no blank line (its doc comment),
+// even when an unrelated decl is declared far above: the nearest decl
+// by actual distance must win, not whichever comes first in the file.
+func TestEnclosingDeclNameDocComment(t *testing.T) {
+	src := `package sample
+
+func Unrelated() {
+	doStuff()
+}
+
+
+
+// TODO: document Bar
+func Bar() {
+}
+`
+	dir := t.TempDir()
+	path := filepath.Join(dir, "doc.go")
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	markers, err := ScanFile(path, ScanOptions{})
+	if err != nil {
+		t.Fatalf("ScanFile: %v", err)
+	}
+	if len(markers) != 1 {
+		t.Fatalf("expected 1 marker, got %d", len(markers))
+	}
+	if markers[0].EnclosingDecl != "Bar" {
+		t.Errorf("EnclosingDecl = %q, want %q", markers[0].EnclosingDecl, "Bar")
+	}
+}
@@ -0,0 +1,522 @@
+// Package validation analyzes Go source for hand-rolled validation logic
+// (Validate/IsValid methods, validateXxx helpers) and proposes replacing
+// it with struct tags consumed by github.com/go-playground/validator.
+package validation
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Rule is a single inferred validator tag for one struct field.
+type Rule struct {
+	Field string `json:"field"`
+	Tag   string `json:"tag"`
+	// Reason is a short human-readable explanation of why the rule was
+	// inferred, used in the report (the patch does not include it).
+	Reason string `json:"reason"`
+}
+
+// Finding describes one hand-rolled check and the rule it implies.
+type Finding struct {
+	File       string `json:"file"`
+	Line       int    `json:"line"`
+	Expr       string `json:"expr"`
+	Func       string `json:"func"`
+	Struct     string `json:"struct"`
+	Field      string `json:"field"`
+	InferredOn string `json:"inferred_rule"`
+}
+
+// StructProposal is the suggested validator-tagged replacement for one
+// struct found to have ad-hoc validation logic.
+type StructProposal struct {
+	Struct string `json:"struct"`
+	File   string `json:"file"`
+	Rules  []Rule `json:"rules"`
+	Patch  string `json:"patch"`
+}
+
+// Report is the result of analyzing a package.
+type Report struct {
+	Findings  []Finding        `json:"findings"`
+	Proposals []StructProposal `json:"proposals"`
+}
+
+// AnalyzeFile parses a single Go source file and reports ad-hoc
+// validation patterns found in it.
+func AnalyzeFile(path string) (*Report, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("validation: parse %s: %w", path, err)
+	}
+
+	structs := collectStructs(file)
+	consts := collectIntConsts(file)
+
+	var findings []Finding
+	ast.Inspect(file, func(n ast.Node) bool {
+		fn, ok := n.(*ast.FuncDecl)
+		if !ok {
+			return true
+		}
+		name := fn.Name.Name
+		switch {
+		case name == "Validate" || name == "IsValid":
+			recv := receiverStruct(fn)
+			findings = append(findings, walkChecks(fset, fn, recv, structs, consts, nil)...)
+		case strings.HasPrefix(name, "validate") && len(name) > len("validate"):
+			params := paramNames(fn)
+			checks := walkChecks(fset, fn, "", structs, consts, params)
+			structName, field := resolveStandaloneTarget(params, structs)
+			for i := range checks {
+				checks[i].Struct = structName
+				if field != "" {
+					checks[i].Field = field
+				}
+			}
+			findings = append(findings, checks...)
+		}
+		return true
+	})
+
+	for i := range findings {
+		findings[i].File = path
+	}
+	proposals := buildProposals(structs, findings, consts)
+	for i := range proposals {
+		proposals[i].File = path
+	}
+
+	return &Report{
+		Findings:  findings,
+		Proposals: proposals,
+	}, nil
+}
+
+// AnalyzeDir analyzes every .go file directly inside dir (non-recursive,
+// mirroring how a single Go package maps to a directory) and merges the
+// results into one Report.
+func AnalyzeDir(dir string) (*Report, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("validation: read dir %s: %w", dir, err)
+	}
+
+	report := &Report{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		fileReport, err := AnalyzeFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		report.Findings = append(report.Findings, fileReport.Findings...)
+		report.Proposals = append(report.Proposals, fileReport.Proposals...)
+	}
+	return report, nil
+}
+
+// structField is one field of a struct found in the package, kept with
+// its declared type so a patch can render the real field type rather
+// than assuming every field is a string.
+type structField struct {
+	name string
+	typ  string
+}
+
+type structInfo struct {
+	name   string
+	file   string
+	fields []structField
+}
+
+func collectStructs(file *ast.File) map[string]*structInfo {
+	out := make(map[string]*structInfo)
+	ast.Inspect(file, func(n ast.Node) bool {
+		ts, ok := n.(*ast.TypeSpec)
+		if !ok {
+			return true
+		}
+		st, ok := ts.Type.(*ast.StructType)
+		if !ok {
+			return true
+		}
+		info := &structInfo{name: ts.Name.Name}
+		for _, f := range st.Fields.List {
+			typ := typeString(f.Type)
+			for _, n := range f.Names {
+				info.fields = append(info.fields, structField{name: n.Name, typ: typ})
+			}
+		}
+		out[ts.Name.Name] = info
+		return true
+	})
+	return out
+}
+
+// typeString renders the source form of a (non-struct-literal) field
+// type well enough for a patch: identifiers, pointers, slices, maps,
+// and package-qualified names.
+func typeString(t ast.Expr) string {
+	switch v := t.(type) {
+	case *ast.Ident:
+		return v.Name
+	case *ast.StarExpr:
+		return "*" + typeString(v.X)
+	case *ast.ArrayType:
+		return "[]" + typeString(v.Elt)
+	case *ast.MapType:
+		return "map[" + typeString(v.Key) + "]" + typeString(v.Value)
+	case *ast.SelectorExpr:
+		return typeString(v.X) + "." + v.Sel.Name
+	default:
+		return "any"
+	}
+}
+
+// collectIntConsts returns the values of top-level constants declared
+// with an integer literal (typed or untyped), keyed by name (e.g. "MaxUsers" -> "1000"), so a bound check
+// against the constant's identifier (`len(u.Name) > MaxUsers`) can be
+// translated into a `max=1000` validator tag.
+func collectIntConsts(file *ast.File) map[string]string {
+	out := make(map[string]string)
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+			for i, name := range vs.Names {
+				if i >= len(vs.Values) {
+					continue
+				}
+				if lit, ok := vs.Values[i].(*ast.BasicLit); ok && lit.Kind == token.INT {
+					out[name.Name] = lit.Value
+				}
+			}
+		}
+	}
+	return out
+}
+
+func receiverStruct(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	switch t := fn.Recv.List[0].Type.(type) {
+	case *ast.StarExpr:
+		if id, ok := t.X.(*ast.Ident); ok {
+			return id.Name
+		}
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+// paramNames returns the set of parameter identifier names declared in
+// fn's signature, so a standalone validateXxx helper's bare-identifier
+// checks (e.g. `email` in `validateEmail(email string)`) can be told
+// apart from unrelated identifiers.
+func paramNames(fn *ast.FuncDecl) map[string]bool {
+	out := make(map[string]bool)
+	if fn.Type.Params == nil {
+		return out
+	}
+	for _, p := range fn.Type.Params.List {
+		for _, n := range p.Names {
+			out[n.Name] = true
+		}
+	}
+	return out
+}
+
+// resolveStandaloneTarget matches a standalone validateXxx helper's
+// parameter names against every struct field in the package
+// (case-insensitively, e.g. param "email" against field "Email") and
+// returns the owning struct and field name, so the helper's findings
+// can be attributed the same way a Validate/IsValid method's receiver
+// field accesses are.
+func resolveStandaloneTarget(params map[string]bool, structs map[string]*structInfo) (string, string) {
+	var structNames []string
+	for name := range structs {
+		structNames = append(structNames, name)
+	}
+	sort.Strings(structNames)
+
+	for _, sname := range structNames {
+		for _, field := range structs[sname].fields {
+			for p := range params {
+				if strings.EqualFold(field.name, p) {
+					return sname, field.name
+				}
+			}
+		}
+	}
+	return "", ""
+}
+
+// walkChecks looks for the three hand-rolled check shapes seen in this
+// codebase: upper-bound comparisons against a named int constant (see
+// maxBoundCheck), equality/emptiness comparisons (`u.Name == ""`), and
+// strings.Contains calls used as a poor man's format check. params, if
+// non-nil, lets a standalone validateXxx helper's bare parameter
+// identifiers (not just `x.Field` selectors) count as field targets.
+func walkChecks(fset *token.FileSet, fn *ast.FuncDecl, recv string, structs map[string]*structInfo, consts map[string]string, params map[string]bool) []Finding {
+	var findings []Finding
+	ast.Inspect(fn, func(n ast.Node) bool {
+		switch expr := n.(type) {
+		case *ast.BinaryExpr:
+			if field, maxConst, ok := maxBoundCheck(expr, consts, params); ok {
+				findings = append(findings, Finding{
+					Line:       fset.Position(expr.Pos()).Line,
+					Expr:       exprString(expr),
+					Func:       fn.Name.Name,
+					Struct:     recv,
+					Field:      field,
+					InferredOn: "max=" + consts[maxConst],
+				})
+				return true
+			}
+
+			sel, ok := fieldSelector(expr.X, params)
+			if !ok {
+				return true
+			}
+			lit, ok := expr.Y.(*ast.BasicLit)
+			if !ok || lit.Value != `""` {
+				return true
+			}
+			rule := "required"
+			findings = append(findings, Finding{
+				Line:       fset.Position(expr.Pos()).Line,
+				Expr:       exprString(expr),
+				Func:       fn.Name.Name,
+				Struct:     recv,
+				Field:      sel,
+				InferredOn: rule,
+			})
+		case *ast.CallExpr:
+			sel, ok := expr.Fun.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "Contains" {
+				return true
+			}
+			pkg, ok := sel.X.(*ast.Ident)
+			if !ok || pkg.Name != "strings" || len(expr.Args) != 2 {
+				return true
+			}
+			field, ok := fieldSelector(expr.Args[0], params)
+			if !ok {
+				return true
+			}
+			substr, ok := expr.Args[1].(*ast.BasicLit)
+			if !ok {
+				return true
+			}
+			rule, ok := inferRuleFromSubstr(substr.Value)
+			if !ok {
+				return true
+			}
+			findings = append(findings, Finding{
+				Line:       fset.Position(expr.Pos()).Line,
+				Expr:       exprString(expr),
+				Func:       fn.Name.Name,
+				Struct:     recv,
+				Field:      field,
+				InferredOn: rule,
+			})
+		}
+		return true
+	})
+	return findings
+}
+
+// maxBoundCheck recognizes an upper-bound comparison against a named int
+// constant, either directly on a field (`u.Count > MaxUsers`) or on its
+// length (`len(u.Name) > MaxUsers`), and returns the checked field and
+// the constant name so the caller can render a `max=N` tag.
+func maxBoundCheck(expr *ast.BinaryExpr, consts map[string]string, params map[string]bool) (field, constName string, ok bool) {
+	switch expr.Op {
+	case token.GTR, token.GEQ:
+	default:
+		return "", "", false
+	}
+
+	constIdent, ok := expr.Y.(*ast.Ident)
+	if !ok {
+		return "", "", false
+	}
+	if _, known := consts[constIdent.Name]; !known {
+		return "", "", false
+	}
+
+	if sel, ok := fieldSelector(expr.X, params); ok {
+		return sel, constIdent.Name, true
+	}
+	if call, ok := expr.X.(*ast.CallExpr); ok {
+		if fn, ok := call.Fun.(*ast.Ident); ok && fn.Name == "len" && len(call.Args) == 1 {
+			if sel, ok := fieldSelector(call.Args[0], params); ok {
+				return sel, constIdent.Name, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// inferRuleFromSubstr maps a strings.Contains substring literal to a
+// validator tag. Only substrings with an unambiguous validator
+// equivalent are recognized; anything else (e.g. a `.`-contains check)
+// isn't a meaningful rule on its own, so ok is false and the caller
+// skips the finding rather than guessing.
+func inferRuleFromSubstr(litValue string) (string, bool) {
+	switch litValue {
+	case `"@"`:
+		return "email", true
+	default:
+		return "", false
+	}
+}
+
+// fieldSelector recognizes a field access (`x.Field`), or, when params
+// is non-nil, a bare identifier that names one of the enclosing
+// function's own parameters (a standalone validateXxx helper checking
+// its argument directly rather than a struct field).
+func fieldSelector(e ast.Expr, params map[string]bool) (string, bool) {
+	if sel, ok := e.(*ast.SelectorExpr); ok {
+		if _, ok := sel.X.(*ast.Ident); !ok {
+			return "", false
+		}
+		return sel.Sel.Name, true
+	}
+	if id, ok := e.(*ast.Ident); ok && params[id.Name] {
+		return id.Name, true
+	}
+	return "", false
+}
+
+func exprString(e ast.Expr) string {
+	var sb strings.Builder
+	ast.Inspect(e, func(n ast.Node) bool {
+		return true
+	})
+	// best-effort textual form; good enough for a diagnostics report.
+	switch v := e.(type) {
+	case *ast.BinaryExpr:
+		sb.WriteString(exprString(v.X))
+		sb.WriteString(" ")
+		sb.WriteString(v.Op.String())
+		sb.WriteString(" ")
+		sb.WriteString(exprString(v.Y))
+	case *ast.SelectorExpr:
+		sb.WriteString(exprString(v.X))
+		sb.WriteString(".")
+		sb.WriteString(v.Sel.Name)
+	case *ast.Ident:
+		sb.WriteString(v.Name)
+	case *ast.BasicLit:
+		sb.WriteString(v.Value)
+	case *ast.CallExpr:
+		sb.WriteString(exprString(v.Fun))
+		sb.WriteString("(")
+		for i, a := range v.Args {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(exprString(a))
+		}
+		sb.WriteString(")")
+	}
+	return sb.String()
+}
+
+// buildProposals merges findings back onto their owning struct and
+// renders a unified-diff-style patch for each one that has at least one
+// inferred rule.
+func buildProposals(structs map[string]*structInfo, findings []Finding, consts map[string]string) []StructProposal {
+	byStruct := make(map[string][]Rule)
+	for _, f := range findings {
+		if f.Struct == "" || f.Field == "" {
+			continue
+		}
+		byStruct[f.Struct] = append(byStruct[f.Struct], Rule{
+			Field:  f.Field,
+			Tag:    f.InferredOn,
+			Reason: fmt.Sprintf("inferred from %s:%d (%s)", f.Func, f.Line, f.Expr),
+		})
+	}
+
+	var names []string
+	for name := range byStruct {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var proposals []StructProposal
+	for _, name := range names {
+		info, ok := structs[name]
+		if !ok {
+			continue
+		}
+		rules := dedupeRules(byStruct[name])
+		proposals = append(proposals, StructProposal{
+			Struct: name,
+			Rules:  rules,
+			Patch:  renderPatch(info, rules),
+		})
+	}
+	return proposals
+}
+
+func dedupeRules(rules []Rule) []Rule {
+	seen := make(map[string]bool)
+	var out []Rule
+	for _, r := range rules {
+		key := r.Field + ":" + r.Tag
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Field < out[j].Field })
+	return out
+}
+
+func renderPatch(info *structInfo, rules []Rule) string {
+	tagFor := make(map[string]string)
+	for _, r := range rules {
+		if existing, ok := tagFor[r.Field]; ok {
+			tagFor[r.Field] = existing + "," + r.Tag
+		} else {
+			tagFor[r.Field] = r.Tag
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- a/%s.go\n+++ b/%s.go\n", info.name, info.name)
+	fmt.Fprintf(&sb, "@@ type %s struct {\n", info.name)
+	for _, field := range info.fields {
+		tag, ok := tagFor[field.name]
+		if !ok {
+			fmt.Fprintf(&sb, "-\t%s %s\n+\t%s %s\n", field.name, field.typ, field.name, field.typ)
+			continue
+		}
+		fmt.Fprintf(&sb, "-\t%s %s\n+\t%s %s `validate:\"%s\"`\n", field.name, field.typ, field.name, field.typ, tag)
+	}
+	sb.WriteString("@@ }\n")
+	fmt.Fprintf(&sb, "-func (x *%s) Validate() error {\n+func (x *%s) Validate() error {\n+\treturn validator.New().Struct(x)\n", info.name, info.name)
+	return sb.String()
+}
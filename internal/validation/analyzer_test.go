@@ -0,0 +1,105 @@
+package validation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeFileSample(t *testing.T) {
+	report, err := AnalyzeFile("../../tests/fixtures/sample.go")
+	if err != nil {
+		t.Fatalf("AnalyzeFile: %v", err)
+	}
+
+	if len(report.Findings) == 0 {
+		t.Fatal("expected at least one finding in sample.go")
+	}
+
+	var proposal *StructProposal
+	for i := range report.Proposals {
+		if report.Proposals[i].Struct == "User" {
+			proposal = &report.Proposals[i]
+		}
+	}
+	if proposal == nil {
+		t.Fatal("expected a proposal for the User struct")
+	}
+
+	want := map[string]string{"Name": "required", "Email": "email"}
+	got := make(map[string]string)
+	for _, r := range proposal.Rules {
+		got[r.Field] = r.Tag
+	}
+	for field, tag := range want {
+		if got[field] != tag {
+			t.Errorf("field %s: got tag %q, want %q", field, got[field], tag)
+		}
+	}
+
+	foundStandalone := false
+	for _, f := range report.Findings {
+		if f.Func == "validateEmail" {
+			foundStandalone = true
+			if f.Struct != "User" || f.Field != "Email" {
+				t.Errorf("validateEmail finding: got struct %q field %q, want User/Email", f.Struct, f.Field)
+			}
+		}
+	}
+	if !foundStandalone {
+		t.Error("expected a finding for the standalone validateEmail helper, matched to User.Email by parameter name")
+	}
+
+	if !strings.Contains(proposal.Patch, "ID int\n") {
+		t.Errorf("expected the patch to render User.ID with its real int type, got:\n%s", proposal.Patch)
+	}
+}
+
+func TestAnalyzeFileMaxBound(t *testing.T) {
+	src := `package sample
+
+const MaxUsers = 1000
+
+type Batch struct {
+	Users []string
+}
+
+func (b *Batch) Validate() error {
+	if len(b.Users) > MaxUsers {
+		return nil
+	}
+	return nil
+}
+`
+	dir := t.TempDir()
+	path := filepath.Join(dir, "batch.go")
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	report, err := AnalyzeFile(path)
+	if err != nil {
+		t.Fatalf("AnalyzeFile: %v", err)
+	}
+
+	var proposal *StructProposal
+	for i := range report.Proposals {
+		if report.Proposals[i].Struct == "Batch" {
+			proposal = &report.Proposals[i]
+		}
+	}
+	if proposal == nil {
+		t.Fatal("expected a proposal for the Batch struct")
+	}
+
+	var gotTag string
+	for _, r := range proposal.Rules {
+		if r.Field == "Users" {
+			gotTag = r.Tag
+		}
+	}
+	if gotTag != "max=1000" {
+		t.Errorf("Users field: got tag %q, want %q", gotTag, "max=1000")
+	}
+}